@@ -0,0 +1,165 @@
+package gooroo
+
+import (
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// DomComponentsParamsTwoWayBinding
+
+// The two-way binding DomComponents update a typed variable when the bound element changes,
+// and write it back into the element after each rendering. The variable is either a typed
+// pointer (*bool, *string ...), whose change triggers a new rendering, or a variable of the
+// store returned by UseState, whose change is also detected by the hooks.
+
+// Returns the DomValue binding the value property of the elements as a string, to the variable
+// passed in parameter.
+func stringDomValue(value *any) *domValue {
+	return &domValue{
+		func(target js.Value) { *value = target.Get(dom.JS_VALUE).String() },
+		func(elem js.Value) { elem.Set(dom.JS_VALUE, *value) },
+		func() { setHasChanged(value, *value) },
+	}
+}
+
+// Returns the DomValue binding the elements to a typed variable (*T) or to a variable of the
+// store (*any) holding a value of type T.
+func typedDomValue[T any](value any, read func(target js.Value) T, write func(elem js.Value, value T)) *domValue {
+	switch v := value.(type) {
+	case *T:
+		return &domValue{
+			func(target js.Value) { *v = read(target) },
+			func(elem js.Value) { write(elem, *v) },
+			updateState,
+		}
+	case *any:
+		return &domValue{
+			func(target js.Value) { *v = convertNumber(read(target), *v) },
+			func(elem js.Value) {
+				// a variable of another type is not written in the element
+				if typed, ok := convertNumber(*v, *new(T)).(T); ok {
+					write(elem, typed)
+				}
+			},
+			func() { setHasChanged(v, *v) },
+		}
+	}
+	return nil
+}
+
+// Converts a number to the numeric type of the reference, so that a variable of the store keeps
+// its type when it is bound (UseState(18) with BindNumber for example). The decimals are dropped
+// for an integer. The other values are returned as is.
+func convertNumber(value any, reference any) any {
+	from, to := reflect.ValueOf(value), reflect.ValueOf(reference)
+	if !from.IsValid() || !to.IsValid() || !isNumber(from.Kind()) || !isNumber(to.Kind()) {
+		return value
+	}
+	return from.Convert(to.Type()).Interface()
+}
+
+// Returns true for the kinds of the integers and floats.
+func isNumber(kind reflect.Kind) bool {
+	return reflect.Int <= kind && kind <= reflect.Float64 && kind != reflect.Uintptr
+}
+
+// Declare a two-way binding on the event 'change' on the attached element, identified by the key.
+func bindTwoWay(key string, value *domValue, params ...BindingParam) DomComponent {
+	param, selector := bindingMarker(key, dom.JS_EVENT_CHANGE)
+	modifier, callbacks := parseBindingParams(params...)
+	bindings[selector] = append(bindings[selector], generateBinding(selector, dom.JS_EVENT_CHANGE, value, modifier, callbacks...))
+	return param
+}
+
+// Declare a two-way binding between the 'checked' property of a checkbox and a boolean.
+func BindChecked[P *bool | *any](checked P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(checked),
+		func(target js.Value) bool { return target.Get(dom.JS_CHECKED).Bool() },
+		func(elem js.Value, checked bool) { elem.Set(dom.JS_CHECKED, checked) },
+	), params...)
+}
+
+// Declare a two-way binding between a group of radio buttons and the value of the checked one.
+// It is attached to each radio of the group, and also declares its attribute 'name='.
+func BindRadio[P *string | *any](group string, value P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	param := bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(value),
+		func(target js.Value) string { return target.Get(dom.JS_VALUE).String() },
+		func(elem js.Value, value string) { elem.Set(dom.JS_CHECKED, elem.Get(dom.JS_VALUE).String() == value) },
+	), params...)
+	name := Attr(dom.HTML_PARAM_NAME, group)
+	return func() string {
+		return name() + " " + strings.TrimPrefix(param(), dom.ELEMENT_PARAM)
+	}
+}
+
+// Declare a two-way binding between the selected option of a <select> and its value.
+func BindSelect[P *string | *any](value P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(value),
+		func(target js.Value) string { return target.Get(dom.JS_VALUE).String() },
+		func(elem js.Value, value string) { elem.Set(dom.JS_VALUE, value) },
+	), params...)
+}
+
+// Declare a two-way binding between the selected options of a <select multiple> and their values.
+func BindMultiSelect[P *[]string | *any](values P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(values),
+		func(target js.Value) []string {
+			selected := target.Get(dom.JS_SELECTED_OPTIONS)
+			values := make([]string, selected.Length())
+			for i := range values {
+				values[i] = selected.Index(i).Get(dom.JS_VALUE).String()
+			}
+			return values
+		},
+		func(elem js.Value, values []string) {
+			options := elem.Get(dom.JS_OPTIONS)
+			for i := 0; i < options.Length(); i++ {
+				options.Index(i).Set(dom.JS_SELECTED, utils.Contains(values, options.Index(i).Get(dom.JS_VALUE).String()))
+			}
+		},
+	), params...)
+}
+
+// Declare a two-way binding between a numeric input and a number. An empty input sets it to 0.
+func BindNumber[P *float64 | *any](value P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(value),
+		func(target js.Value) float64 {
+			number := target.Get(dom.JS_VALUE_AS_NUMBER).Float()
+			if math.IsNaN(number) {
+				return 0
+			}
+			return number
+		},
+		func(elem js.Value, value float64) { elem.Set(dom.JS_VALUE_AS_NUMBER, value) },
+	), params...)
+}
+
+// Declare a two-way binding between a date input and a time. An empty input sets it to the zero time.
+func BindDate[P *time.Time | *any](value P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(value),
+		func(target js.Value) time.Time {
+			date, _ := time.Parse(dom.JS_DATE_FORMAT, target.Get(dom.JS_VALUE).String())
+			return date
+		},
+		func(elem js.Value, value time.Time) {
+			if value.IsZero() {
+				elem.Set(dom.JS_VALUE, "")
+			} else {
+				elem.Set(dom.JS_VALUE, value.Format(dom.JS_DATE_FORMAT))
+			}
+		},
+	), params...)
+}
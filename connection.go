@@ -0,0 +1,130 @@
+package gooroo
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Connections
+
+// The connections are the streams of messages opened by the hooks (UseWebSocket, UseEventSource).
+// They are reconnected with an exponential backoff, and closed when they are no longer used.
+
+// SocketStatus describes the state of a connection.
+type SocketStatus string
+
+const SOCKET_CONNECTING SocketStatus = "connecting"
+const SOCKET_OPEN SocketStatus = "open"
+const SOCKET_CLOSED SocketStatus = "closed"
+
+// ConnectionOption modifies the behavior of both kinds of connections.
+type ConnectionOption func(options *connectionOptions)
+
+// WebSocketOption modifies the behavior of a WebSocket: a ConnectionOption or SocketProtocols.
+type WebSocketOption interface {
+	applyWebSocket(options *connectionOptions)
+}
+
+// EventSourceOption modifies the behavior of an EventSource: a ConnectionOption, WithCredentials
+// or HandleEvent.
+type EventSourceOption interface {
+	applyEventSource(options *connectionOptions)
+}
+
+// Options accepted only by UseWebSocket and UseEventSource.
+type webSocketOption func(options *connectionOptions)
+type eventSourceOption func(options *connectionOptions)
+
+// Options of a connection.
+type connectionOptions struct {
+	protocols       []string
+	withCredentials bool
+	minDelay        time.Duration
+	maxDelay        time.Duration
+	maxReconnects   int
+	handlers        map[string]func(data string) error
+}
+
+// Returns the options of a connection, with their default values.
+func newConnectionOptions() connectionOptions {
+	return connectionOptions{nil, false, 500 * time.Millisecond, 30 * time.Second, 0, make(map[string]func(data string) error)}
+}
+
+func (option ConnectionOption) applyWebSocket(options *connectionOptions) {
+	option(options)
+}
+
+func (option ConnectionOption) applyEventSource(options *connectionOptions) {
+	option(options)
+}
+
+func (option webSocketOption) applyWebSocket(options *connectionOptions) {
+	option(options)
+}
+
+func (option eventSourceOption) applyEventSource(options *connectionOptions) {
+	option(options)
+}
+
+// Sets the subprotocols requested to the server by a WebSocket.
+func SocketProtocols(protocols ...string) WebSocketOption {
+	return webSocketOption(func(options *connectionOptions) { options.protocols = protocols })
+}
+
+// Sends the cookies of the page with the requests of an EventSource to another origin.
+func WithCredentials() EventSourceOption {
+	return eventSourceOption(func(options *connectionOptions) { options.withCredentials = true })
+}
+
+// Sets the delay before the first reconnection, doubled at each failed attempt up to the maximum
+// delay. 500ms and 30s by default.
+func ReconnectBackoff(minDelay time.Duration, maxDelay time.Duration) ConnectionOption {
+	return func(options *connectionOptions) { options.minDelay, options.maxDelay = minDelay, maxDelay }
+}
+
+// Sets the maximum number of reconnections in a row, negative to disable them. 0 (unlimited) by default.
+func MaxReconnects(count int) ConnectionOption {
+	return func(options *connectionOptions) { options.maxReconnects = count }
+}
+
+// Listens to the named events of an EventSource, and calls the handler with the payload of each
+// one, decoded from JSON (or the raw data if T is a string), on the render loop. A handler added
+// while the EventSource is open starts listening to its events at once.
+func HandleEvent[T any](event string, handler func(payload T)) EventSourceOption {
+	return eventSourceOption(func(options *connectionOptions) {
+		options.handlers[event] = func(data string) error {
+			payload, err := decodeMessage[T](data)
+			if err == nil {
+				handler(payload)
+			}
+			return err
+		}
+	})
+}
+
+// Returns the delay before a reconnection after a number of failed attempts, and false if
+// the connection must not be reconnected anymore.
+func (o connectionOptions) backoff(attempts int) (time.Duration, bool) {
+	if o.maxReconnects < 0 || (o.maxReconnects > 0 && attempts >= o.maxReconnects) {
+		return 0, false
+	}
+	delay := o.minDelay << attempts
+	if delay > o.maxDelay || delay <= 0 {
+		delay = o.maxDelay
+	}
+	return delay, true
+}
+
+// Decodes a message from JSON, or returns it as is if T is a string or a []byte.
+func decodeMessage[T any](message string) (T, error) {
+	var decoded T
+	if text, isText := any(message).(T); isText {
+		return text, nil
+	}
+	if bytes, isBytes := any(&decoded).(*[]byte); isBytes {
+		*bytes = []byte(message)
+		return decoded, nil
+	}
+	err := json.Unmarshal([]byte(message), &decoded)
+	return decoded, err
+}
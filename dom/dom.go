@@ -11,49 +11,6 @@ const HTML_SCRIPT = "script"
 const HTML_STYLESHEET = "stylesheet"
 const HTML_BODY = "body"
 const HTML_DIV = "div"
-const HTML_DIV_OPENER = "<div>"
-const HTML_DIV_CLOSER = "</div>"
-const HTML_P_OPENER = "<p>"
-const HTML_P_CLOSER = "</p>"
-const HTML_SPAN_OPENER = "<span>"
-const HTML_SPAN_CLOSER = "</span>"
-const HTML_BR_OPENER = "<br>"
-const HTML_HR_OPENER = "<hr>"
-const HTML_UL_OPENER = "<ul>"
-const HTML_UL_CLOSER = "</ul>"
-const HTML_LI_OPENER = "<li>"
-const HTML_LI_CLOSER = "</li>"
-const HTML_TABLE_OPENER = "<table>"
-const HTML_TABLE_CLOSER = "</table>"
-const HTML_TR_OPENER = "<tr>"
-const HTML_TR_CLOSER = "</tr>"
-const HTML_TH_OPENER = "<th>"
-const HTML_TH_CLOSER = "</th>"
-const HTML_TD_OPENER = "<td>"
-const HTML_TD_CLOSER = "</td>"
-const HTML_H1_OPENER = "<h1>"
-const HTML_H1_CLOSER = "</h1>"
-const HTML_H2_OPENER = "<h2>"
-const HTML_H2_CLOSER = "</h2>"
-const HTML_H3_OPENER = "<h3>"
-const HTML_H3_CLOSER = "</h3>"
-const HTML_H4_OPENER = "<h4>"
-const HTML_H4_CLOSER = "</h4>"
-const HTML_A_OPENER = "<a>"
-const HTML_A_CLOSER = "</a>"
-const HTML_IMG_OPENER = "<img>"
-const HTML_I_OPENER = "<i>"
-const HTML_FORM_OPENER = "<form>"
-const HTML_FORM_CLOSER = "</form>"
-const HTML_INPUT_OPENER = "<input>"
-const HTML_TEXTAREA_OPENER = "<textarea>"
-const HTML_TEXTAREA_CLOSER = "</textarea>"
-const HTML_SELECT_OPENER = "<select>"
-const HTML_SELECT_CLOSER = "</select>"
-const HTML_OPTION_OPENER = "<option>"
-const HTML_OPTION_CLOSER = "</option>"
-const HTML_BUTTON_OPENER = "<button>"
-const HTML_BUTTON_CLOSER = "</button>"
 
 const HTML_PARAM_CLASSNAME = "class="
 const HTML_PARAM_STYLE = "style="
@@ -64,6 +21,17 @@ const HTML_PARAM_ID = "id="
 const HTML_PARAM_TYPE = "type="
 const HTML_PARAM_PLACEHOLDER = "placeholder="
 const HTML_PARAM_TITLE = "title="
+const HTML_PARAM_NAME = "name"
+const HTML_PARAM_DATA = "data-"
+const HTML_PARAM_ARIA = "aria-"
+const HTML_PARAM_GOOROO = "data-gooroo-"
+const HTML_PARAM_DISABLED = "disabled"
+const HTML_PARAM_CHECKED = "checked"
+const HTML_PARAM_REQUIRED = "required"
+const HTML_PARAM_READONLY = "readonly"
+const HTML_PARAM_HIDDEN = "hidden"
+const HTML_PARAM_SELECTED = "selected"
+const HTML_QUOTE_ESCAPED = "&#39;"
 
 const CSS_PARAM_DISPLAY = "display:"
 const CSS_PARAM_DISPLAY_FLEX = "flex"
@@ -78,6 +46,7 @@ const CSS_PARAM_GRID_REPEAT_OPENER = "repeat("
 const CSS_PARAM_GRID_REPEAT_CLOSER = ", 1fr)"
 
 const JS_CREATE_ELEMENT = "createElement"
+const JS_CREATE_ELEMENT_NS = "createElementNS"
 const JS_APPEND_CHILD = "appendChild"
 const JS_INNER_HTML = "innerHTML"
 const JS_TEXT_CONTENT = "textContent"
@@ -86,13 +55,204 @@ const JS_HREF = "href"
 const JS_SRC = "src"
 const JS_GET_ELEMENT_BY_ID = "getElementById"
 const JS_GET_ELEMENT_BY_CLASSNAME = "getElementsByClassName"
+const JS_QUERY_SELECTOR = "querySelector"
+const JS_QUERY_SELECTOR_ALL = "querySelectorAll"
 const JS_ADD_EVENT_LISTENER = "addEventListener"
 const JS_TARGET = "target"
 const JS_VALUE = "value"
+const JS_DISABLED = "disabled"
+const JS_CHECKED = "checked"
+const JS_REQUIRED = "required"
+const JS_READ_ONLY = "readOnly"
+const JS_HIDDEN = "hidden"
+const JS_SELECTED = "selected"
 const JS_EVENT_CLICK = "click"
 const JS_EVENT_KEYUP = "keyup"
 const JS_EVENT_KEYDOWN = "keydown"
 const JS_EVENT_CHANGE = "change"
 const JS_EVENT_FOCUS = "focus"
+const JS_EVENT_INPUT = "input"
+const JS_EVENT_SUBMIT = "submit"
+const JS_EVENT_BLUR = "blur"
+const JS_EVENT_MOUSEENTER = "mouseenter"
+const JS_EVENT_MOUSELEAVE = "mouseleave"
+const JS_EVENT_DBLCLICK = "dblclick"
+const JS_EVENT_SCROLL = "scroll"
+const JS_EVENT_CONTEXTMENU = "contextmenu"
+const JS_EVENT_POINTERDOWN = "pointerdown"
+const JS_EVENT_POINTERUP = "pointerup"
+const JS_EVENT_POINTERMOVE = "pointermove"
+const JS_EVENT_POINTERENTER = "pointerenter"
+const JS_EVENT_POINTERLEAVE = "pointerleave"
+const JS_EVENT_POINTERCANCEL = "pointercancel"
+const JS_EVENT_PREFIX = "on"
+const JS_TYPE = "type"
+const JS_PREVENT_DEFAULT = "preventDefault"
+const JS_STOP_PROPAGATION = "stopPropagation"
+const JS_STOP_IMMEDIATE_PROPAGATION = "stopImmediatePropagation"
+const JS_CURRENT_TARGET = "currentTarget"
+const JS_CLIENT_X = "clientX"
+const JS_CLIENT_Y = "clientY"
+const JS_PAGE_X = "pageX"
+const JS_PAGE_Y = "pageY"
+const JS_OFFSET_X = "offsetX"
+const JS_OFFSET_Y = "offsetY"
+const JS_BUTTON = "button"
+const JS_BUTTONS = "buttons"
+const JS_ALT_KEY = "altKey"
+const JS_CTRL_KEY = "ctrlKey"
+const JS_SHIFT_KEY = "shiftKey"
+const JS_META_KEY = "metaKey"
+const JS_KEY = "key"
+const JS_CODE = "code"
+const JS_REPEAT = "repeat"
+const JS_DATA = "data"
+const JS_INPUT_TYPE = "inputType"
+const JS_SUBMITTER = "submitter"
+const JS_FORM_DATA = "FormData"
+const JS_GET = "get"
+const JS_DATA_TRANSFER = "dataTransfer"
+const JS_GET_DATA = "getData"
+const JS_OPTION_PASSIVE = "passive"
+const JS_OPTION_CAPTURE = "capture"
+const JS_PARENT_ELEMENT = "parentElement"
+const JS_ATTRIBUTES = "attributes"
+const JS_NAME = "name"
+const JS_BUBBLES = "bubbles"
+const JS_CANCEL_BUBBLE = "cancelBubble"
+const JS_EVENT_PHASE = "eventPhase"
+const JS_EVENT_PHASE_CAPTURING = 1
+const JS_VALUE_AS_NUMBER = "valueAsNumber"
+const JS_OPTIONS = "options"
+const JS_SELECTED_OPTIONS = "selectedOptions"
+const JS_DATE_FORMAT = "2006-01-02"
+const JS_ACTIVE_ELEMENT = "activeElement"
+const JS_SELECTION_START = "selectionStart"
+const JS_SELECTION_END = "selectionEnd"
+const JS_SELECTION_DIRECTION = "selectionDirection"
+const JS_SET_SELECTION_RANGE = "setSelectionRange"
+const JS_PREVENT_SCROLL = "preventScroll"
+const JS_SCROLL_TOP = "scrollTop"
+const JS_SCROLL_LEFT = "scrollLeft"
+const JS_SCROLL_X = "scrollX"
+const JS_SCROLL_Y = "scrollY"
+const JS_SCROLL_TO = "scrollTo"
+const JS_TAG_NAME = "tagName"
+const JS_IS_CONNECTED = "isConnected"
+const JS_SET = "Set"
+const JS_ADD = "add"
+const JS_CLEAR = "clear"
+const JS_ARRAY = "Array"
+const JS_FROM = "from"
+const JS_PROTOTYPE = "prototype"
+const JS_INDEX_OF = "indexOf"
+const JS_CALL = "call"
+const JS_SET_DATA = "setData"
 const JS_CHILDREN = "children"
 const JS_LENGTH = "length"
+const JS_NAMESPACE_URI = "namespaceURI"
+const JS_LOCAL_NAME = "localName"
+const JS_FIRST_CHILD = "firstChild"
+const JS_FILES = "files"
+const JS_SIZE = "size"
+const JS_LAST_MODIFIED = "lastModified"
+const JS_FILE_READER = "FileReader"
+const JS_READ_AS_ARRAY_BUFFER = "readAsArrayBuffer"
+const JS_RESULT = "result"
+const JS_ERROR = "error"
+const JS_MESSAGE = "message"
+const JS_UINT8_ARRAY = "Uint8Array"
+const JS_XML_HTTP_REQUEST = "XMLHttpRequest"
+const JS_UPLOAD = "upload"
+const JS_OPEN = "open"
+const JS_SEND = "send"
+const JS_ABORT = "abort"
+const JS_APPEND = "append"
+const JS_STATUS = "status"
+const JS_RESPONSE_TEXT = "responseText"
+const JS_LOADED = "loaded"
+const JS_TOTAL = "total"
+const JS_LENGTH_COMPUTABLE = "lengthComputable"
+const JS_EVENT_LOAD = "load"
+const JS_EVENT_ERROR = "error"
+const JS_EVENT_ABORT = "abort"
+const JS_EVENT_PROGRESS = "progress"
+const HTTP_METHOD_POST = "POST"
+const JS_FETCH = "fetch"
+const JS_ABORT_CONTROLLER = "AbortController"
+const JS_SIGNAL = "signal"
+const JS_METHOD = "method"
+const JS_HEADERS = "headers"
+const JS_BODY = "body"
+const JS_THEN = "then"
+const JS_STATUS_TEXT = "statusText"
+const JS_ARRAY_BUFFER = "arrayBuffer"
+const JS_FOR_EACH = "forEach"
+const JS_URL = "url"
+const HTTP_METHOD_GET = "GET"
+const HTTP_METHOD_PUT = "PUT"
+const HTTP_METHOD_DELETE = "DELETE"
+const HTTP_HEADER_CONTENT_TYPE = "Content-Type"
+const HTTP_HEADER_ACCEPT = "Accept"
+const HTTP_HEADER_AUTHORIZATION = "Authorization"
+const HTTP_CONTENT_TYPE_JSON = "application/json"
+const JS_STRING = "String"
+const JS_WEB_SOCKET = "WebSocket"
+const JS_CLOSE = "close"
+const JS_EVENT_OPEN = "open"
+const JS_EVENT_MESSAGE = "message"
+const JS_EVENT_CLOSE = "close"
+const JS_REMOVE_EVENT_LISTENER = "removeEventListener"
+const JS_CLOSE_NORMAL = 1000
+const JS_EVENT_SOURCE = "EventSource"
+const JS_WITH_CREDENTIALS = "withCredentials"
+const JS_READY_STATE = "readyState"
+const JS_LAST_EVENT_ID = "lastEventId"
+const JS_READY_STATE_CLOSED = 2
+const JS_GET_ITEM = "getItem"
+const JS_SET_ITEM = "setItem"
+const JS_REMOVE_ITEM = "removeItem"
+const JS_NEW_VALUE = "newValue"
+const JS_STORAGE_AREA = "storageArea"
+const JS_EVENT_STORAGE = "storage"
+const JS_INDEXED_DB = "indexedDB"
+const JS_DELETE_DATABASE = "deleteDatabase"
+const JS_TRANSACTION = "transaction"
+const JS_OBJECT_STORE = "objectStore"
+const JS_CREATE_OBJECT_STORE = "createObjectStore"
+const JS_DELETE_OBJECT_STORE = "deleteObjectStore"
+const JS_CREATE_INDEX = "createIndex"
+const JS_DELETE_INDEX = "deleteIndex"
+const JS_INDEX = "index"
+const JS_PUT = "put"
+const JS_DELETE = "delete"
+const JS_GET_ALL = "getAll"
+const JS_COUNT = "count"
+const JS_OPEN_CURSOR = "openCursor"
+const JS_CONTINUE = "continue"
+const JS_KEY_PATH = "keyPath"
+const JS_AUTO_INCREMENT = "autoIncrement"
+const JS_UNIQUE = "unique"
+const JS_OLD_VERSION = "oldVersion"
+const JS_JSON = "JSON"
+const JS_PARSE = "parse"
+const JS_STRINGIFY = "stringify"
+const JS_DATE = "Date"
+const JS_EVENT_SUCCESS = "success"
+const JS_EVENT_COMPLETE = "complete"
+const JS_EVENT_UPGRADE_NEEDED = "upgradeneeded"
+const JS_SET_ATTRIBUTE = "setAttribute"
+const JS_REMOVE_ATTRIBUTE = "removeAttribute"
+const HTML_PARAM_SIGNAL = "signal-"
+const JS_GET_ATTRIBUTE = "getAttribute"
+const JS_ID = "id"
+const HTML_TYPE_CHECKBOX = "checkbox"
+const HTML_TYPE_SUBMIT = "submit"
+const HTML_ARIA_INVALID = "invalid"
+const JS_BINARY_TYPE = "binaryType"
+const JS_BINARY_TYPE_ARRAY_BUFFER = "arraybuffer"
+const JS_REFLECT = "Reflect"
+const JS_EVENT_BLOCKED = "blocked"
+const JS_EVENT_VERSION_CHANGE = "versionchange"
+const JS_GET_TIME = "getTime"
+const JS_IS_ARRAY = "isArray"
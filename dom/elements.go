@@ -0,0 +1,214 @@
+// Code generated by dom/gen from dom/elements.txt; DO NOT EDIT.
+
+package dom
+
+const HTML_DIV_OPENER = "<div>"
+const HTML_DIV_CLOSER = "</div>"
+const HTML_SECTION_OPENER = "<section>"
+const HTML_SECTION_CLOSER = "</section>"
+const HTML_NAV_OPENER = "<nav>"
+const HTML_NAV_CLOSER = "</nav>"
+const HTML_HEADER_OPENER = "<header>"
+const HTML_HEADER_CLOSER = "</header>"
+const HTML_FOOTER_OPENER = "<footer>"
+const HTML_FOOTER_CLOSER = "</footer>"
+const HTML_MAIN_OPENER = "<main>"
+const HTML_MAIN_CLOSER = "</main>"
+const HTML_ARTICLE_OPENER = "<article>"
+const HTML_ARTICLE_CLOSER = "</article>"
+const HTML_ASIDE_OPENER = "<aside>"
+const HTML_ASIDE_CLOSER = "</aside>"
+const HTML_ADDRESS_OPENER = "<address>"
+const HTML_ADDRESS_CLOSER = "</address>"
+const HTML_HGROUP_OPENER = "<hgroup>"
+const HTML_HGROUP_CLOSER = "</hgroup>"
+const HTML_SEARCH_OPENER = "<search>"
+const HTML_SEARCH_CLOSER = "</search>"
+const HTML_H1_OPENER = "<h1>"
+const HTML_H1_CLOSER = "</h1>"
+const HTML_H2_OPENER = "<h2>"
+const HTML_H2_CLOSER = "</h2>"
+const HTML_H3_OPENER = "<h3>"
+const HTML_H3_CLOSER = "</h3>"
+const HTML_H4_OPENER = "<h4>"
+const HTML_H4_CLOSER = "</h4>"
+const HTML_H5_OPENER = "<h5>"
+const HTML_H5_CLOSER = "</h5>"
+const HTML_H6_OPENER = "<h6>"
+const HTML_H6_CLOSER = "</h6>"
+const HTML_P_OPENER = "<p>"
+const HTML_P_CLOSER = "</p>"
+const HTML_HR_OPENER = "<hr>"
+const HTML_PRE_OPENER = "<pre>"
+const HTML_PRE_CLOSER = "</pre>"
+const HTML_BLOCKQUOTE_OPENER = "<blockquote>"
+const HTML_BLOCKQUOTE_CLOSER = "</blockquote>"
+const HTML_OL_OPENER = "<ol>"
+const HTML_OL_CLOSER = "</ol>"
+const HTML_UL_OPENER = "<ul>"
+const HTML_UL_CLOSER = "</ul>"
+const HTML_MENU_OPENER = "<menu>"
+const HTML_MENU_CLOSER = "</menu>"
+const HTML_LI_OPENER = "<li>"
+const HTML_LI_CLOSER = "</li>"
+const HTML_DL_OPENER = "<dl>"
+const HTML_DL_CLOSER = "</dl>"
+const HTML_DT_OPENER = "<dt>"
+const HTML_DT_CLOSER = "</dt>"
+const HTML_DD_OPENER = "<dd>"
+const HTML_DD_CLOSER = "</dd>"
+const HTML_FIGURE_OPENER = "<figure>"
+const HTML_FIGURE_CLOSER = "</figure>"
+const HTML_FIGCAPTION_OPENER = "<figcaption>"
+const HTML_FIGCAPTION_CLOSER = "</figcaption>"
+const HTML_A_OPENER = "<a>"
+const HTML_A_CLOSER = "</a>"
+const HTML_EM_OPENER = "<em>"
+const HTML_EM_CLOSER = "</em>"
+const HTML_STRONG_OPENER = "<strong>"
+const HTML_STRONG_CLOSER = "</strong>"
+const HTML_SMALL_OPENER = "<small>"
+const HTML_SMALL_CLOSER = "</small>"
+const HTML_S_OPENER = "<s>"
+const HTML_S_CLOSER = "</s>"
+const HTML_CITE_OPENER = "<cite>"
+const HTML_CITE_CLOSER = "</cite>"
+const HTML_Q_OPENER = "<q>"
+const HTML_Q_CLOSER = "</q>"
+const HTML_DFN_OPENER = "<dfn>"
+const HTML_DFN_CLOSER = "</dfn>"
+const HTML_ABBR_OPENER = "<abbr>"
+const HTML_ABBR_CLOSER = "</abbr>"
+const HTML_RUBY_OPENER = "<ruby>"
+const HTML_RUBY_CLOSER = "</ruby>"
+const HTML_RT_OPENER = "<rt>"
+const HTML_RT_CLOSER = "</rt>"
+const HTML_RP_OPENER = "<rp>"
+const HTML_RP_CLOSER = "</rp>"
+const HTML_DATA_OPENER = "<data>"
+const HTML_DATA_CLOSER = "</data>"
+const HTML_TIME_OPENER = "<time>"
+const HTML_TIME_CLOSER = "</time>"
+const HTML_CODE_OPENER = "<code>"
+const HTML_CODE_CLOSER = "</code>"
+const HTML_VAR_OPENER = "<var>"
+const HTML_VAR_CLOSER = "</var>"
+const HTML_SAMP_OPENER = "<samp>"
+const HTML_SAMP_CLOSER = "</samp>"
+const HTML_KBD_OPENER = "<kbd>"
+const HTML_KBD_CLOSER = "</kbd>"
+const HTML_SUB_OPENER = "<sub>"
+const HTML_SUB_CLOSER = "</sub>"
+const HTML_SUP_OPENER = "<sup>"
+const HTML_SUP_CLOSER = "</sup>"
+const HTML_I_OPENER = "<i>"
+const HTML_I_CLOSER = "</i>"
+const HTML_B_OPENER = "<b>"
+const HTML_B_CLOSER = "</b>"
+const HTML_U_OPENER = "<u>"
+const HTML_U_CLOSER = "</u>"
+const HTML_MARK_OPENER = "<mark>"
+const HTML_MARK_CLOSER = "</mark>"
+const HTML_BDI_OPENER = "<bdi>"
+const HTML_BDI_CLOSER = "</bdi>"
+const HTML_BDO_OPENER = "<bdo>"
+const HTML_BDO_CLOSER = "</bdo>"
+const HTML_SPAN_OPENER = "<span>"
+const HTML_SPAN_CLOSER = "</span>"
+const HTML_BR_OPENER = "<br>"
+const HTML_WBR_OPENER = "<wbr>"
+const HTML_INS_OPENER = "<ins>"
+const HTML_INS_CLOSER = "</ins>"
+const HTML_DEL_OPENER = "<del>"
+const HTML_DEL_CLOSER = "</del>"
+const HTML_PICTURE_OPENER = "<picture>"
+const HTML_PICTURE_CLOSER = "</picture>"
+const HTML_SOURCE_OPENER = "<source>"
+const HTML_IMG_OPENER = "<img>"
+const HTML_IFRAME_OPENER = "<iframe>"
+const HTML_IFRAME_CLOSER = "</iframe>"
+const HTML_EMBED_OPENER = "<embed>"
+const HTML_OBJECT_OPENER = "<object>"
+const HTML_OBJECT_CLOSER = "</object>"
+const HTML_VIDEO_OPENER = "<video>"
+const HTML_VIDEO_CLOSER = "</video>"
+const HTML_AUDIO_OPENER = "<audio>"
+const HTML_AUDIO_CLOSER = "</audio>"
+const HTML_TRACK_OPENER = "<track>"
+const HTML_MAP_OPENER = "<map>"
+const HTML_MAP_CLOSER = "</map>"
+const HTML_AREA_OPENER = "<area>"
+const HTML_CANVAS_OPENER = "<canvas>"
+const HTML_CANVAS_CLOSER = "</canvas>"
+const HTML_TABLE_OPENER = "<table>"
+const HTML_TABLE_CLOSER = "</table>"
+const HTML_CAPTION_OPENER = "<caption>"
+const HTML_CAPTION_CLOSER = "</caption>"
+const HTML_COLGROUP_OPENER = "<colgroup>"
+const HTML_COLGROUP_CLOSER = "</colgroup>"
+const HTML_COL_OPENER = "<col>"
+const HTML_THEAD_OPENER = "<thead>"
+const HTML_THEAD_CLOSER = "</thead>"
+const HTML_TBODY_OPENER = "<tbody>"
+const HTML_TBODY_CLOSER = "</tbody>"
+const HTML_TFOOT_OPENER = "<tfoot>"
+const HTML_TFOOT_CLOSER = "</tfoot>"
+const HTML_TR_OPENER = "<tr>"
+const HTML_TR_CLOSER = "</tr>"
+const HTML_TH_OPENER = "<th>"
+const HTML_TH_CLOSER = "</th>"
+const HTML_TD_OPENER = "<td>"
+const HTML_TD_CLOSER = "</td>"
+const HTML_FORM_OPENER = "<form>"
+const HTML_FORM_CLOSER = "</form>"
+const HTML_LABEL_OPENER = "<label>"
+const HTML_LABEL_CLOSER = "</label>"
+const HTML_INPUT_OPENER = "<input>"
+const HTML_BUTTON_OPENER = "<button>"
+const HTML_BUTTON_CLOSER = "</button>"
+const HTML_SELECT_OPENER = "<select>"
+const HTML_SELECT_CLOSER = "</select>"
+const HTML_DATALIST_OPENER = "<datalist>"
+const HTML_DATALIST_CLOSER = "</datalist>"
+const HTML_OPTGROUP_OPENER = "<optgroup>"
+const HTML_OPTGROUP_CLOSER = "</optgroup>"
+const HTML_OPTION_OPENER = "<option>"
+const HTML_OPTION_CLOSER = "</option>"
+const HTML_TEXTAREA_OPENER = "<textarea>"
+const HTML_TEXTAREA_CLOSER = "</textarea>"
+const HTML_OUTPUT_OPENER = "<output>"
+const HTML_OUTPUT_CLOSER = "</output>"
+const HTML_PROGRESS_OPENER = "<progress>"
+const HTML_PROGRESS_CLOSER = "</progress>"
+const HTML_METER_OPENER = "<meter>"
+const HTML_METER_CLOSER = "</meter>"
+const HTML_FIELDSET_OPENER = "<fieldset>"
+const HTML_FIELDSET_CLOSER = "</fieldset>"
+const HTML_LEGEND_OPENER = "<legend>"
+const HTML_LEGEND_CLOSER = "</legend>"
+const HTML_DETAILS_OPENER = "<details>"
+const HTML_DETAILS_CLOSER = "</details>"
+const HTML_SUMMARY_OPENER = "<summary>"
+const HTML_SUMMARY_CLOSER = "</summary>"
+const HTML_DIALOG_OPENER = "<dialog>"
+const HTML_DIALOG_CLOSER = "</dialog>"
+const HTML_NOSCRIPT_OPENER = "<noscript>"
+const HTML_NOSCRIPT_CLOSER = "</noscript>"
+const HTML_TEMPLATE_OPENER = "<template>"
+const HTML_TEMPLATE_CLOSER = "</template>"
+const HTML_SLOT_OPENER = "<slot>"
+const HTML_SLOT_CLOSER = "</slot>"
+
+// List of the elements that have no closing tag.
+var HTML_VOID_ELEMENTS = []string{
+	"hr",
+	"br",
+	"wbr",
+	"source",
+	"img",
+	"embed",
+	"track",
+	"area",
+	"col",
+	"input",
+}
@@ -0,0 +1,143 @@
+// This program generates the HTML element constants of the dom package and the matching
+// DomComponent constructors of the gooroo package from the dom/elements.txt specification.
+// It is run through `go generate` at the root of the module.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+const (
+	specFile = "dom/elements.txt"
+	domFile  = "dom/elements.go"
+	rootFile = "elements.go"
+	header   = "// Code generated by dom/gen from dom/elements.txt; DO NOT EDIT.\n\n"
+)
+
+const (
+	kindContainer = "container"
+	kindText      = "text"
+	kindVoid      = "void"
+)
+
+// Element represents one line of the specification.
+type element struct {
+	tag  string
+	name string
+	kind string
+}
+
+func main() {
+	elements, err := readSpec(specFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := writeGo(domFile, generateDom(elements)); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeGo(rootFile, generateRoot(elements)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Reads the specification file, ignoring blank lines and '#' comments.
+func readSpec(path string) ([]element, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var elements []element
+	tags := make(map[string]bool)
+	names := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for no := 1; scanner.Scan(); no++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected 3 columns, got %d", path, no, len(fields))
+		}
+		elem := element{fields[0], fields[1], fields[2]}
+		if elem.kind != kindContainer && elem.kind != kindText && elem.kind != kindVoid {
+			return nil, fmt.Errorf("%s:%d: unknown kind %q", path, no, elem.kind)
+		}
+		if tags[elem.tag] || names[elem.name] {
+			return nil, fmt.Errorf("%s:%d: duplicate element %q", path, no, elem.tag)
+		}
+		tags[elem.tag] = true
+		names[elem.name] = true
+		elements = append(elements, elem)
+	}
+	return elements, scanner.Err()
+}
+
+// Formats and writes a generated Go source file.
+func writeGo(path string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
+
+// Returns the name of the constant holding the opening (or closing) tag of an element.
+func constName(elem element, suffix string) string {
+	return fmt.Sprintf("HTML_%s_%s", strings.ToUpper(elem.tag), suffix)
+}
+
+// Generates the opener / closer constants and the list of void elements of the dom package.
+func generateDom(elements []element) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package dom\n\n")
+	for _, elem := range elements {
+		fmt.Fprintf(&buf, "const %s = \"<%s>\"\n", constName(elem, "OPENER"), elem.tag)
+		if elem.kind != kindVoid {
+			fmt.Fprintf(&buf, "const %s = \"</%s>\"\n", constName(elem, "CLOSER"), elem.tag)
+		}
+	}
+	buf.WriteString("\n// List of the elements that have no closing tag.\n")
+	buf.WriteString("var HTML_VOID_ELEMENTS = []string{\n")
+	for _, elem := range elements {
+		if elem.kind == kindVoid {
+			fmt.Fprintf(&buf, "\t%q,\n", elem.tag)
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// Generates the DomComponent constructors of the gooroo package.
+func generateRoot(elements []element) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package gooroo\n\n")
+	buf.WriteString("import (\n\t\"fmt\"\n\n\t\"github.com/Matbabs/Gooroo/dom\"\n\t\"github.com/Matbabs/Gooroo/utils\"\n)\n")
+	for _, elem := range elements {
+		fmt.Fprintf(&buf, "\n// Declare an html element with the <%s> tag.\n", elem.tag)
+		switch elem.kind {
+		case kindContainer:
+			fmt.Fprintf(&buf, "func %s(insiders ...DomComponent) DomComponent {\n", elem.name)
+			fmt.Fprintf(&buf, "\treturn htmlDomComponent(dom.%s, dom.%s, insiders...)\n", constName(elem, "OPENER"), constName(elem, "CLOSER"))
+		case kindText:
+			fmt.Fprintf(&buf, "func %s[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {\n", elem.name)
+			buf.WriteString("\ttextStr := utils.AnyStr(text)\n")
+			buf.WriteString("\tsanitizeHtml(&textStr)\n")
+			fmt.Fprintf(&buf, "\treturn htmlDomComponent(fmt.Sprintf(\"%%s%%s\", dom.%s, textStr), dom.%s, insiders...)\n", constName(elem, "OPENER"), constName(elem, "CLOSER"))
+		case kindVoid:
+			fmt.Fprintf(&buf, "func %s(insiders ...DomComponent) DomComponent {\n", elem.name)
+			fmt.Fprintf(&buf, "\treturn htmlDomComponent(dom.%s, \"\", insiders...)\n", constName(elem, "OPENER"))
+		}
+		buf.WriteString("}\n")
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,76 @@
+package dom
+
+const SVG_NAMESPACE = "http://www.w3.org/2000/svg"
+const MATHML_NAMESPACE = "http://www.w3.org/1998/Math/MathML"
+const XLINK_NAMESPACE = "http://www.w3.org/1999/xlink"
+
+const SVG_SVG = "svg"
+const SVG_G = "g"
+const SVG_DEFS = "defs"
+const SVG_SYMBOL = "symbol"
+const SVG_USE = "use"
+const SVG_TITLE = "title"
+const SVG_DESC = "desc"
+const SVG_PATH = "path"
+const SVG_CIRCLE = "circle"
+const SVG_ELLIPSE = "ellipse"
+const SVG_RECT = "rect"
+const SVG_LINE = "line"
+const SVG_POLYLINE = "polyline"
+const SVG_POLYGON = "polygon"
+const SVG_TEXT = "text"
+const SVG_TSPAN = "tspan"
+const SVG_IMAGE = "image"
+const SVG_FOREIGN_OBJECT = "foreignObject"
+const SVG_LINEAR_GRADIENT = "linearGradient"
+const SVG_RADIAL_GRADIENT = "radialGradient"
+const SVG_STOP = "stop"
+const SVG_CLIP_PATH = "clipPath"
+const SVG_MASK = "mask"
+const SVG_PATTERN = "pattern"
+const SVG_MARKER = "marker"
+
+const SVG_PARAM_VIEWBOX = "viewBox"
+const SVG_PARAM_WIDTH = "width"
+const SVG_PARAM_HEIGHT = "height"
+const SVG_PARAM_X = "x"
+const SVG_PARAM_Y = "y"
+const SVG_PARAM_CX = "cx"
+const SVG_PARAM_CY = "cy"
+const SVG_PARAM_R = "r"
+const SVG_PARAM_RX = "rx"
+const SVG_PARAM_RY = "ry"
+const SVG_PARAM_X1 = "x1"
+const SVG_PARAM_Y1 = "y1"
+const SVG_PARAM_X2 = "x2"
+const SVG_PARAM_Y2 = "y2"
+const SVG_PARAM_D = "d"
+const SVG_PARAM_POINTS = "points"
+const SVG_PARAM_FILL = "fill"
+const SVG_PARAM_STROKE = "stroke"
+const SVG_PARAM_STROKE_WIDTH = "stroke-width"
+const SVG_PARAM_OPACITY = "opacity"
+const SVG_PARAM_TRANSFORM = "transform"
+const SVG_PARAM_HREF = "href"
+const SVG_PARAM_OFFSET = "offset"
+const SVG_PARAM_STOP_COLOR = "stop-color"
+const SVG_PARAM_TEXT_ANCHOR = "text-anchor"
+const SVG_PARAM_PRESERVE_ASPECT_RATIO = "preserveAspectRatio"
+
+const MATHML_MATH = "math"
+const MATHML_MROW = "mrow"
+const MATHML_MI = "mi"
+const MATHML_MN = "mn"
+const MATHML_MO = "mo"
+const MATHML_MTEXT = "mtext"
+const MATHML_MFRAC = "mfrac"
+const MATHML_MSQRT = "msqrt"
+const MATHML_MROOT = "mroot"
+const MATHML_MSUP = "msup"
+const MATHML_MSUB = "msub"
+const MATHML_MSUBSUP = "msubsup"
+const MATHML_MTABLE = "mtable"
+const MATHML_MTR = "mtr"
+const MATHML_MTD = "mtd"
+
+const MATHML_PARAM_DISPLAY = "display"
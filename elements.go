@@ -0,0 +1,609 @@
+// Code generated by dom/gen from dom/elements.txt; DO NOT EDIT.
+
+package gooroo
+
+import (
+	"fmt"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Declare an html element with the <div> tag.
+func Div(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_DIV_OPENER, dom.HTML_DIV_CLOSER, insiders...)
+}
+
+// Declare an html element with the <section> tag.
+func Section(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_SECTION_OPENER, dom.HTML_SECTION_CLOSER, insiders...)
+}
+
+// Declare an html element with the <nav> tag.
+func Nav(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_NAV_OPENER, dom.HTML_NAV_CLOSER, insiders...)
+}
+
+// Declare an html element with the <header> tag.
+func Header(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_HEADER_OPENER, dom.HTML_HEADER_CLOSER, insiders...)
+}
+
+// Declare an html element with the <footer> tag.
+func Footer(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_FOOTER_OPENER, dom.HTML_FOOTER_CLOSER, insiders...)
+}
+
+// Declare an html element with the <main> tag.
+func Main(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_MAIN_OPENER, dom.HTML_MAIN_CLOSER, insiders...)
+}
+
+// Declare an html element with the <article> tag.
+func Article(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_ARTICLE_OPENER, dom.HTML_ARTICLE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <aside> tag.
+func Aside(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_ASIDE_OPENER, dom.HTML_ASIDE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <address> tag.
+func Address(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_ADDRESS_OPENER, dom.HTML_ADDRESS_CLOSER, insiders...)
+}
+
+// Declare an html element with the <hgroup> tag.
+func Hgroup(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_HGROUP_OPENER, dom.HTML_HGROUP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <search> tag.
+func Search(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_SEARCH_OPENER, dom.HTML_SEARCH_CLOSER, insiders...)
+}
+
+// Declare an html element with the <h1> tag.
+func H1[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H1_OPENER, textStr), dom.HTML_H1_CLOSER, insiders...)
+}
+
+// Declare an html element with the <h2> tag.
+func H2[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H2_OPENER, textStr), dom.HTML_H2_CLOSER, insiders...)
+}
+
+// Declare an html element with the <h3> tag.
+func H3[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H3_OPENER, textStr), dom.HTML_H3_CLOSER, insiders...)
+}
+
+// Declare an html element with the <h4> tag.
+func H4[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H4_OPENER, textStr), dom.HTML_H4_CLOSER, insiders...)
+}
+
+// Declare an html element with the <h5> tag.
+func H5[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H5_OPENER, textStr), dom.HTML_H5_CLOSER, insiders...)
+}
+
+// Declare an html element with the <h6> tag.
+func H6[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H6_OPENER, textStr), dom.HTML_H6_CLOSER, insiders...)
+}
+
+// Declare an html element with the <p> tag.
+func P[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_P_OPENER, textStr), dom.HTML_P_CLOSER, insiders...)
+}
+
+// Declare an html element with the <hr> tag.
+func Hr(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_HR_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <pre> tag.
+func Pre[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_PRE_OPENER, textStr), dom.HTML_PRE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <blockquote> tag.
+func Blockquote(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_BLOCKQUOTE_OPENER, dom.HTML_BLOCKQUOTE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <ol> tag.
+func Ol(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_OL_OPENER, dom.HTML_OL_CLOSER, insiders...)
+}
+
+// Declare an html element with the <ul> tag.
+func Ul(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_UL_OPENER, dom.HTML_UL_CLOSER, insiders...)
+}
+
+// Declare an html element with the <menu> tag.
+func Menu(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_MENU_OPENER, dom.HTML_MENU_CLOSER, insiders...)
+}
+
+// Declare an html element with the <li> tag.
+func Li(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_LI_OPENER, dom.HTML_LI_CLOSER, insiders...)
+}
+
+// Declare an html element with the <dl> tag.
+func Dl(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_DL_OPENER, dom.HTML_DL_CLOSER, insiders...)
+}
+
+// Declare an html element with the <dt> tag.
+func Dt[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_DT_OPENER, textStr), dom.HTML_DT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <dd> tag.
+func Dd[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_DD_OPENER, textStr), dom.HTML_DD_CLOSER, insiders...)
+}
+
+// Declare an html element with the <figure> tag.
+func Figure(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_FIGURE_OPENER, dom.HTML_FIGURE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <figcaption> tag.
+func Figcaption[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_FIGCAPTION_OPENER, textStr), dom.HTML_FIGCAPTION_CLOSER, insiders...)
+}
+
+// Declare an html element with the <a> tag.
+func A[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_A_OPENER, textStr), dom.HTML_A_CLOSER, insiders...)
+}
+
+// Declare an html element with the <em> tag.
+func Em[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_EM_OPENER, textStr), dom.HTML_EM_CLOSER, insiders...)
+}
+
+// Declare an html element with the <strong> tag.
+func Strong[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_STRONG_OPENER, textStr), dom.HTML_STRONG_CLOSER, insiders...)
+}
+
+// Declare an html element with the <small> tag.
+func Small[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SMALL_OPENER, textStr), dom.HTML_SMALL_CLOSER, insiders...)
+}
+
+// Declare an html element with the <s> tag.
+func S[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_S_OPENER, textStr), dom.HTML_S_CLOSER, insiders...)
+}
+
+// Declare an html element with the <cite> tag.
+func Cite[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_CITE_OPENER, textStr), dom.HTML_CITE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <q> tag.
+func Q[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_Q_OPENER, textStr), dom.HTML_Q_CLOSER, insiders...)
+}
+
+// Declare an html element with the <dfn> tag.
+func Dfn[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_DFN_OPENER, textStr), dom.HTML_DFN_CLOSER, insiders...)
+}
+
+// Declare an html element with the <abbr> tag.
+func Abbr[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_ABBR_OPENER, textStr), dom.HTML_ABBR_CLOSER, insiders...)
+}
+
+// Declare an html element with the <ruby> tag.
+func Ruby(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_RUBY_OPENER, dom.HTML_RUBY_CLOSER, insiders...)
+}
+
+// Declare an html element with the <rt> tag.
+func Rt[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_RT_OPENER, textStr), dom.HTML_RT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <rp> tag.
+func Rp[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_RP_OPENER, textStr), dom.HTML_RP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <data> tag.
+func DataElem[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_DATA_OPENER, textStr), dom.HTML_DATA_CLOSER, insiders...)
+}
+
+// Declare an html element with the <time> tag.
+func Time[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_TIME_OPENER, textStr), dom.HTML_TIME_CLOSER, insiders...)
+}
+
+// Declare an html element with the <code> tag.
+func Code[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_CODE_OPENER, textStr), dom.HTML_CODE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <var> tag.
+func Var[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_VAR_OPENER, textStr), dom.HTML_VAR_CLOSER, insiders...)
+}
+
+// Declare an html element with the <samp> tag.
+func Samp[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SAMP_OPENER, textStr), dom.HTML_SAMP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <kbd> tag.
+func Kbd[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_KBD_OPENER, textStr), dom.HTML_KBD_CLOSER, insiders...)
+}
+
+// Declare an html element with the <sub> tag.
+func Sub[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SUB_OPENER, textStr), dom.HTML_SUB_CLOSER, insiders...)
+}
+
+// Declare an html element with the <sup> tag.
+func Sup[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SUP_OPENER, textStr), dom.HTML_SUP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <i> tag.
+func I(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_I_OPENER, dom.HTML_I_CLOSER, insiders...)
+}
+
+// Declare an html element with the <b> tag.
+func B[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_B_OPENER, textStr), dom.HTML_B_CLOSER, insiders...)
+}
+
+// Declare an html element with the <u> tag.
+func U[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_U_OPENER, textStr), dom.HTML_U_CLOSER, insiders...)
+}
+
+// Declare an html element with the <mark> tag.
+func Mark[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_MARK_OPENER, textStr), dom.HTML_MARK_CLOSER, insiders...)
+}
+
+// Declare an html element with the <bdi> tag.
+func Bdi[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_BDI_OPENER, textStr), dom.HTML_BDI_CLOSER, insiders...)
+}
+
+// Declare an html element with the <bdo> tag.
+func Bdo[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_BDO_OPENER, textStr), dom.HTML_BDO_CLOSER, insiders...)
+}
+
+// Declare an html element with the <span> tag.
+func Span[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SPAN_OPENER, textStr), dom.HTML_SPAN_CLOSER, insiders...)
+}
+
+// Declare an html element with the <br> tag.
+func Br(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_BR_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <wbr> tag.
+func Wbr(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_WBR_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <ins> tag.
+func Ins(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_INS_OPENER, dom.HTML_INS_CLOSER, insiders...)
+}
+
+// Declare an html element with the <del> tag.
+func Del(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_DEL_OPENER, dom.HTML_DEL_CLOSER, insiders...)
+}
+
+// Declare an html element with the <picture> tag.
+func Picture(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_PICTURE_OPENER, dom.HTML_PICTURE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <source> tag.
+func Source(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_SOURCE_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <img> tag.
+func Img(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_IMG_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <iframe> tag.
+func Iframe(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_IFRAME_OPENER, dom.HTML_IFRAME_CLOSER, insiders...)
+}
+
+// Declare an html element with the <embed> tag.
+func Embed(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_EMBED_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <object> tag.
+func Object(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_OBJECT_OPENER, dom.HTML_OBJECT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <video> tag.
+func Video(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_VIDEO_OPENER, dom.HTML_VIDEO_CLOSER, insiders...)
+}
+
+// Declare an html element with the <audio> tag.
+func Audio(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_AUDIO_OPENER, dom.HTML_AUDIO_CLOSER, insiders...)
+}
+
+// Declare an html element with the <track> tag.
+func Track(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TRACK_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <map> tag.
+func Map(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_MAP_OPENER, dom.HTML_MAP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <area> tag.
+func Area(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_AREA_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <canvas> tag.
+func Canvas(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_CANVAS_OPENER, dom.HTML_CANVAS_CLOSER, insiders...)
+}
+
+// Declare an html element with the <table> tag.
+func Table(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TABLE_OPENER, dom.HTML_TABLE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <caption> tag.
+func Caption[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_CAPTION_OPENER, textStr), dom.HTML_CAPTION_CLOSER, insiders...)
+}
+
+// Declare an html element with the <colgroup> tag.
+func Colgroup(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_COLGROUP_OPENER, dom.HTML_COLGROUP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <col> tag.
+func Col(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_COL_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <thead> tag.
+func Thead(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_THEAD_OPENER, dom.HTML_THEAD_CLOSER, insiders...)
+}
+
+// Declare an html element with the <tbody> tag.
+func Tbody(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TBODY_OPENER, dom.HTML_TBODY_CLOSER, insiders...)
+}
+
+// Declare an html element with the <tfoot> tag.
+func Tfoot(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TFOOT_OPENER, dom.HTML_TFOOT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <tr> tag.
+func Tr(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TR_OPENER, dom.HTML_TR_CLOSER, insiders...)
+}
+
+// Declare an html element with the <th> tag.
+func Th(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TH_OPENER, dom.HTML_TH_CLOSER, insiders...)
+}
+
+// Declare an html element with the <td> tag.
+func Td(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TD_OPENER, dom.HTML_TD_CLOSER, insiders...)
+}
+
+// Declare an html element with the <form> tag.
+func Form(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_FORM_OPENER, dom.HTML_FORM_CLOSER, insiders...)
+}
+
+// Declare an html element with the <label> tag.
+func Label[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_LABEL_OPENER, textStr), dom.HTML_LABEL_CLOSER, insiders...)
+}
+
+// Declare an html element with the <input> tag.
+func Input(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_INPUT_OPENER, "", insiders...)
+}
+
+// Declare an html element with the <button> tag.
+func Button[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_BUTTON_OPENER, textStr), dom.HTML_BUTTON_CLOSER, insiders...)
+}
+
+// Declare an html element with the <select> tag.
+func Select(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_SELECT_OPENER, dom.HTML_SELECT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <datalist> tag.
+func Datalist(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_DATALIST_OPENER, dom.HTML_DATALIST_CLOSER, insiders...)
+}
+
+// Declare an html element with the <optgroup> tag.
+func Optgroup(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_OPTGROUP_OPENER, dom.HTML_OPTGROUP_CLOSER, insiders...)
+}
+
+// Declare an html element with the <option> tag.
+func Option[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_OPTION_OPENER, textStr), dom.HTML_OPTION_CLOSER, insiders...)
+}
+
+// Declare an html element with the <textarea> tag.
+func TextArea(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TEXTAREA_OPENER, dom.HTML_TEXTAREA_CLOSER, insiders...)
+}
+
+// Declare an html element with the <output> tag.
+func Output(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_OUTPUT_OPENER, dom.HTML_OUTPUT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <progress> tag.
+func Progress(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_PROGRESS_OPENER, dom.HTML_PROGRESS_CLOSER, insiders...)
+}
+
+// Declare an html element with the <meter> tag.
+func Meter(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_METER_OPENER, dom.HTML_METER_CLOSER, insiders...)
+}
+
+// Declare an html element with the <fieldset> tag.
+func Fieldset(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_FIELDSET_OPENER, dom.HTML_FIELDSET_CLOSER, insiders...)
+}
+
+// Declare an html element with the <legend> tag.
+func Legend[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_LEGEND_OPENER, textStr), dom.HTML_LEGEND_CLOSER, insiders...)
+}
+
+// Declare an html element with the <details> tag.
+func Details(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_DETAILS_OPENER, dom.HTML_DETAILS_CLOSER, insiders...)
+}
+
+// Declare an html element with the <summary> tag.
+func Summary[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+	textStr := utils.AnyStr(text)
+	sanitizeHtml(&textStr)
+	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SUMMARY_OPENER, textStr), dom.HTML_SUMMARY_CLOSER, insiders...)
+}
+
+// Declare an html element with the <dialog> tag.
+func Dialog(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_DIALOG_OPENER, dom.HTML_DIALOG_CLOSER, insiders...)
+}
+
+// Declare an html element with the <noscript> tag.
+func Noscript(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_NOSCRIPT_OPENER, dom.HTML_NOSCRIPT_CLOSER, insiders...)
+}
+
+// Declare an html element with the <template> tag.
+func Template(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_TEMPLATE_OPENER, dom.HTML_TEMPLATE_CLOSER, insiders...)
+}
+
+// Declare an html element with the <slot> tag.
+func Slot(insiders ...DomComponent) DomComponent {
+	return htmlDomComponent(dom.HTML_SLOT_OPENER, dom.HTML_SLOT_CLOSER, insiders...)
+}
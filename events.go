@@ -0,0 +1,353 @@
+package gooroo
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// DomComponentsParamsBinding
+
+// The binding DomComponents accept as parameters the callbacks of the event, either raw
+// func(js.Value) or typed (func(MouseEvent), func(KeyboardEvent) ...), and EventModifiers.
+
+// BindingParam is a parameter of the binding DomComponents: an EventModifier, a callback receiving
+// the raw JavaScript event (func(js.Value)) or a typed one (func(MouseEvent) ...). The binding
+// DomComponents panic with any other value.
+type BindingParam = any
+
+// EventModifier changes the way a binding listens to its event: options of the listener
+// (once, passive, capture), default action and propagation of the event, and filters on
+// the events that trigger the callbacks.
+type EventModifier struct {
+	prevent  bool
+	stop     bool
+	once     bool
+	passive  bool
+	capture  bool
+	lazy     bool
+	debounce time.Duration
+	throttle time.Duration
+	filters  []func(js.Value) bool
+}
+
+// Cancels the default action of the browser before the callbacks (the reload of the page on
+// the submission of a form for example).
+func Prevent() EventModifier {
+	return EventModifier{prevent: true}
+}
+
+// Prevents the event from being dispatched to the bindings of the parent elements. The propagation
+// of the JavaScript event itself is stopped only for a capture binding (with Capture()), since the
+// bindings are triggered from the root of the application.
+func Stop() EventModifier {
+	return EventModifier{stop: true}
+}
+
+// Triggers the callbacks only once, across the renderings. The binding can be triggered again
+// once it has not been rendered, as when its element is unmounted.
+func Once() EventModifier {
+	return EventModifier{once: true}
+}
+
+// Declares a listener that never cancels the default action, allowing smooth scrolling.
+func Passive() EventModifier {
+	return EventModifier{passive: true}
+}
+
+// Triggers the callbacks during the capture phase, before the children of the element.
+func Capture() EventModifier {
+	return EventModifier{capture: true}
+}
+
+// Delays the callbacks, and the update of the state for a value binding, until no event has
+// been emitted for the duration passed in parameter. If several durations are passed to
+// a binding, the longest one is kept, and Debounce() takes precedence over Throttle().
+func Debounce(duration time.Duration) EventModifier {
+	return EventModifier{debounce: duration}
+}
+
+// Triggers the callbacks, and the update of the state for a value binding, at most once per
+// duration passed in parameter. The last event of an interval is handled at its end.
+// If several durations are passed to a binding, the longest one is kept. Ignored with Debounce().
+func Throttle(duration time.Duration) EventModifier {
+	return EventModifier{throttle: duration}
+}
+
+// Updates the value of OnChange only on the event 'change' (when the input loses the focus),
+// instead of at each keystroke.
+func Lazy() EventModifier {
+	return EventModifier{lazy: true}
+}
+
+// Triggers the callbacks only for the keyboard events of one of the keys passed in parameter
+// ("Enter", "Escape", "a" ...).
+func Key(keys ...string) EventModifier {
+	return EventModifier{filters: []func(js.Value) bool{func(e js.Value) bool {
+		key := e.Get(dom.JS_KEY)
+		return key.Type() == js.TypeString && utils.Contains(keys, key.String())
+	}}}
+}
+
+const ONCE_RESOURCE_PREFIX = "once:"
+
+// Combines the EventModifier with another one. The longest of the durations is kept.
+func (m EventModifier) merge(other EventModifier) EventModifier {
+	return EventModifier{
+		m.prevent || other.prevent,
+		m.stop || other.stop,
+		m.once || other.once,
+		m.passive || other.passive,
+		m.capture || other.capture,
+		m.lazy || other.lazy,
+		utils.MaxDuration(m.debounce, other.debounce),
+		utils.MaxDuration(m.throttle, other.throttle),
+		append(append([]func(js.Value) bool{}, m.filters...), other.filters...),
+	}
+}
+
+// Returns only the timing (debounce and throttle) of the EventModifier.
+func (m EventModifier) timing() EventModifier {
+	return EventModifier{debounce: m.debounce, throttle: m.throttle}
+}
+
+// Splits the parameters of a binding DomComponent between the EventModifiers, merged together,
+// and the callbacks, adapted to receive the raw JavaScript event.
+func parseBindingParams(params ...any) (EventModifier, []func(js.Value)) {
+	modifier := EventModifier{}
+	callbacks := []func(js.Value){}
+	for _, param := range params {
+		switch p := param.(type) {
+		case EventModifier:
+			modifier = modifier.merge(p)
+		case func(js.Value):
+			callbacks = append(callbacks, p)
+		case func(Event):
+			callbacks = append(callbacks, EventHandler(p))
+		case func(MouseEvent):
+			callbacks = append(callbacks, MouseHandler(p))
+		case func(KeyboardEvent):
+			callbacks = append(callbacks, KeyboardHandler(p))
+		case func(InputEvent):
+			callbacks = append(callbacks, InputHandler(p))
+		case func(SubmitEvent):
+			callbacks = append(callbacks, SubmitHandler(p))
+		case func(DragEvent):
+			callbacks = append(callbacks, DragHandler(p))
+		default:
+			panic(fmt.Sprintf("gooroo: unsupported binding parameter of type %T", param))
+		}
+	}
+	return modifier, callbacks
+}
+
+// Marks the attached element with the attribute 'data-gooroo-on<event>=' and the key of the
+// binding, and returns the selector of the elements marked. The key is suffixed with the number
+// of bindings already declared at the same position, so each element of a loop has its own.
+// The keys of the bindings of the same event on an element are merged in one attribute by
+// mergeBindingMarkers.
+func bindingMarker(key string, event string) (DomComponent, string) {
+	marker := fmt.Sprintf("%s%s%s", dom.HTML_PARAM_GOOROO, dom.JS_EVENT_PREFIX, event)
+	occurrence := bindingOccurrences[marker+key]
+	bindingOccurrences[marker+key]++
+	key = fmt.Sprintf("%s#%d", key, occurrence)
+	return func() string {
+		return fmt.Sprintf("%s%s='%s'", dom.ELEMENT_PARAM, marker, key)
+	}, bindingSelector(marker, key)
+}
+
+// Returns the selector of the elements marked with the binding attribute and its key.
+func bindingSelector(marker string, key string) string {
+	return fmt.Sprintf("[%s~='%s']", marker, key)
+}
+
+// Matches a binding attribute in the opening tag of an element, with its name and its keys.
+var bindingMarkerPattern = regexp.MustCompile(" (" + regexp.QuoteMeta(dom.HTML_PARAM_GOOROO+dom.JS_EVENT_PREFIX) + `[\w-]+)='([^']*)'`)
+
+// Merges the binding attributes of the same event in the opening tag of an element into the
+// first one, as a list of keys separated by spaces, since an element keeps only the first of
+// duplicated attributes.
+func mergeBindingMarkers(opener string) string {
+	if strings.Count(opener, dom.HTML_PARAM_GOOROO+dom.JS_EVENT_PREFIX) < 2 {
+		return opener
+	}
+	keys := make(map[string][]string)
+	for _, match := range bindingMarkerPattern.FindAllStringSubmatch(opener, -1) {
+		keys[match[1]] = append(keys[match[1]], match[2])
+	}
+	merged := make(map[string]bool)
+	return bindingMarkerPattern.ReplaceAllStringFunc(opener, func(attribute string) string {
+		marker := bindingMarkerPattern.FindStringSubmatch(attribute)[1]
+		if merged[marker] {
+			return ""
+		}
+		merged[marker] = true
+		return fmt.Sprintf(" %s='%s'", marker, strings.Join(keys[marker], " "))
+	})
+}
+
+// Declare a binding on the event passed in parameter on the attached element, identified by
+// the key, to trigger the functions passed in parameter.
+func bindEvent(key string, event string, params ...BindingParam) DomComponent {
+	param, selector := bindingMarker(key, event)
+	modifier, callbacks := parseBindingParams(params...)
+	bindings[selector] = append(bindings[selector], generateBinding(selector, event, nil, modifier, callbacks...))
+	return param
+}
+
+// Declare a binding on any event on the attached element to trigger
+// the function passed in parameter.
+func On(event string, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), utils.SanitizeName(event), params...)
+}
+
+// Declare a binding on the event 'click' on the attached element to trigger
+// the function passed in parameter.
+func OnClick(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_CLICK, params...)
+}
+
+// Declare a binding on the event 'change' on the attached element to trigger
+// the function passed in parameter. The value is updated at each input, unless
+// the binding is Lazy(), and the state change can be delayed with Debounce() or Throttle().
+func OnChange(value *any, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	param, selector := bindingMarker(utils.CallerToKey(file, no), dom.JS_EVENT_CHANGE)
+	modifier, callbacks := parseBindingParams(params...)
+	var domValue *domValue
+	if value != nil {
+		domValue = stringDomValue(value)
+	}
+	bindings[selector] = append(bindings[selector], generateBinding(selector, dom.JS_EVENT_CHANGE, domValue, modifier, callbacks...))
+	if !modifier.lazy {
+		bindings[selector] = append(bindings[selector], generateBinding(selector, dom.JS_EVENT_INPUT, domValue, modifier.timing()))
+	}
+	return param
+}
+
+// Declare a binding on the event 'input' on the attached element to trigger
+// the function passed in parameter.
+func OnInput(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_INPUT, params...)
+}
+
+// Declare a binding on the event 'submit' on the attached element to trigger
+// the function passed in parameter.
+func OnSubmit(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_SUBMIT, params...)
+}
+
+// Declare a binding on the event 'keydown' on the attached element to trigger
+// the function passed in parameter.
+func OnKeyDown(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_KEYDOWN, params...)
+}
+
+// Declare a binding on the event 'keyup' on the attached element to trigger
+// the function passed in parameter.
+func OnKeyUp(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_KEYUP, params...)
+}
+
+// Declare a binding on the event 'focus' on the attached element to trigger
+// the function passed in parameter.
+func OnFocus(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_FOCUS, params...)
+}
+
+// Declare a binding on the event 'blur' on the attached element to trigger
+// the function passed in parameter.
+func OnBlur(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_BLUR, params...)
+}
+
+// Declare a binding on the event 'mouseenter' on the attached element to trigger
+// the function passed in parameter.
+func OnMouseEnter(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_MOUSEENTER, params...)
+}
+
+// Declare a binding on the event 'mouseleave' on the attached element to trigger
+// the function passed in parameter.
+func OnMouseLeave(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_MOUSELEAVE, params...)
+}
+
+// Declare a binding on the event 'dblclick' on the attached element to trigger
+// the function passed in parameter.
+func OnDoubleClick(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_DBLCLICK, params...)
+}
+
+// Declare a binding on the event 'scroll' on the attached element to trigger
+// the function passed in parameter.
+func OnScroll(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_SCROLL, params...)
+}
+
+// Declare a binding on the event 'contextmenu' on the attached element to trigger
+// the function passed in parameter.
+func OnContextMenu(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_CONTEXTMENU, params...)
+}
+
+// Declare a binding on the event 'pointerdown' on the attached element to trigger
+// the function passed in parameter.
+func OnPointerDown(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_POINTERDOWN, params...)
+}
+
+// Declare a binding on the event 'pointerup' on the attached element to trigger
+// the function passed in parameter.
+func OnPointerUp(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_POINTERUP, params...)
+}
+
+// Declare a binding on the event 'pointermove' on the attached element to trigger
+// the function passed in parameter.
+func OnPointerMove(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_POINTERMOVE, params...)
+}
+
+// Declare a binding on the event 'pointerenter' on the attached element to trigger
+// the function passed in parameter.
+func OnPointerEnter(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_POINTERENTER, params...)
+}
+
+// Declare a binding on the event 'pointerleave' on the attached element to trigger
+// the function passed in parameter.
+func OnPointerLeave(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_POINTERLEAVE, params...)
+}
+
+// Declare a binding on the event 'pointercancel' on the attached element to trigger
+// the function passed in parameter.
+func OnPointerCancel(params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindEvent(utils.CallerToKey(file, no), dom.JS_EVENT_POINTERCANCEL, params...)
+}
@@ -0,0 +1,197 @@
+package gooroo
+
+import (
+	"errors"
+	"runtime"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Server-Sent Events
+
+const EVENT_SOURCE_RESOURCE_PREFIX = "eventsource:"
+
+// EventSource describes the state of the stream opened by UseEventSource.
+type EventSource[T any] struct {
+	// State of the connection.
+	Status SocketStatus
+	// Name of the last event received.
+	Event string
+	// Payload of the last 'message' event, decoded from JSON (or the raw data if T is a string).
+	// The payloads of the named events are only passed to their handler.
+	Message T
+	// Id of the last event received.
+	LastEventId string
+	// Error of the connection or of the decoding of the last event, or nil.
+	Error error
+}
+
+// EventSourceEntry retains an EventSource and its state across the renderings.
+type eventSourceEntry struct {
+	url         string
+	options     connectionOptions
+	source      js.Value
+	listeners   map[string]js.Func
+	status      SocketStatus
+	event       string
+	message     string
+	lastEventId string
+	received    bool
+	err         error
+	attempts    int
+	reconnect   *time.Timer
+	closed      bool
+}
+
+// Store of the EventSources, by position of their hook.
+var eventSources = make(map[string]*eventSourceEntry)
+
+// Returns the state of a stream of Server-Sent Events from the URL, with the payload of its last
+// 'message' event decoded in a value of type T. The named events of the handlers (HandleEvent)
+// are also listened, including the handlers added after the connection. Each event renders the
+// application again. The stream reconnects by itself after a network error, and is reconnected
+// with an exponential backoff if the server closes it. It is closed when the component using it
+// is unmounted.
+func UseEventSource[T any](url string, options ...EventSourceOption) EventSource[T] {
+	_, file, no, _ := runtime.Caller(1)
+	key := utils.CallerToKey(file, no)
+	utils.MapInit(key, eventSources, &eventSourceEntry{})
+	entry := eventSources[key]
+	entry.options = newConnectionOptions()
+	for _, option := range options {
+		option.applyEventSource(&entry.options)
+	}
+	useResource(EVENT_SOURCE_RESOURCE_PREFIX+key, func() {
+		entry.close()
+		delete(eventSources, key)
+	})
+	if entry.url != url {
+		entry.close()
+		entry.url, entry.closed, entry.attempts = url, false, 0
+		entry.received, entry.event, entry.message, entry.lastEventId = false, "", "", ""
+		entry.connect()
+	} else if entry.source.Truthy() {
+		entry.listen()
+	}
+	es := EventSource[T]{Status: entry.status, Event: entry.event, LastEventId: entry.lastEventId, Error: entry.err}
+	if entry.received {
+		message, err := decodeMessage[T](entry.message)
+		es.Message = message
+		if err != nil && es.Error == nil {
+			es.Error = err
+		}
+	}
+	return es
+}
+
+// Opens the EventSource, and listens to its events on the render loop.
+func (entry *eventSourceEntry) connect() {
+	entry.source = js.Global().Get(dom.JS_EVENT_SOURCE).New(entry.url, map[string]any{
+		dom.JS_WITH_CREDENTIALS: entry.options.withCredentials,
+	})
+	entry.status = SOCKET_CONNECTING
+	source := entry.source
+	entry.listeners = map[string]js.Func{
+		dom.JS_EVENT_OPEN: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			Update(func() {
+				entry.status, entry.err, entry.attempts = SOCKET_OPEN, nil, 0
+			})
+			return nil
+		}),
+		dom.JS_EVENT_ERROR: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			closed := source.Get(dom.JS_READY_STATE).Int() == dom.JS_READY_STATE_CLOSED
+			Update(func() {
+				if !entry.source.Equal(source) {
+					return
+				}
+				entry.err = errors.New("eventsource: connection error")
+				if closed {
+					// closed by the server: the browser does not reconnect it
+					entry.status = SOCKET_CLOSED
+					entry.scheduleReconnect()
+				} else {
+					entry.status = SOCKET_CONNECTING
+				}
+			})
+			return nil
+		}),
+	}
+	for event, listener := range entry.listeners {
+		source.Call(dom.JS_ADD_EVENT_LISTENER, event, listener)
+	}
+	entry.listen()
+}
+
+// Listens to the 'message' events and to the named events of the handlers, if not already listened.
+func (entry *eventSourceEntry) listen() {
+	events := []string{dom.JS_EVENT_MESSAGE}
+	for event := range entry.options.handlers {
+		events = append(events, event)
+	}
+	for _, event := range events {
+		if _, isListened := entry.listeners[event]; isListened {
+			continue
+		}
+		event := event
+		entry.listeners[event] = js.FuncOf(func(_ js.Value, args []js.Value) any {
+			data := args[0].Get(dom.JS_DATA).String()
+			lastEventId := args[0].Get(dom.JS_LAST_EVENT_ID).String()
+			Update(func() {
+				entry.event, entry.lastEventId = event, lastEventId
+				if event == dom.JS_EVENT_MESSAGE {
+					entry.message, entry.received = data, true
+				}
+				if handler, isPresent := entry.options.handlers[event]; isPresent {
+					entry.err = handler(data)
+				}
+			})
+			return nil
+		})
+		entry.source.Call(dom.JS_ADD_EVENT_LISTENER, event, entry.listeners[event])
+	}
+}
+
+// Reconnects the EventSource after a delay doubled at each failed attempt, unless it has been
+// closed or replaced meanwhile (by a change of URL after the delay).
+func (entry *eventSourceEntry) scheduleReconnect() {
+	delay, canReconnect := entry.options.backoff(entry.attempts)
+	if entry.closed || !canReconnect {
+		return
+	}
+	entry.attempts++
+	source := entry.source
+	entry.reconnect = time.AfterFunc(delay, func() {
+		Update(func() {
+			if !entry.closed && entry.source.Equal(source) {
+				entry.releaseListeners()
+				entry.connect()
+			}
+		})
+	})
+}
+
+// Closes the EventSource and stops its reconnections.
+func (entry *eventSourceEntry) close() {
+	entry.closed = true
+	if entry.reconnect != nil {
+		entry.reconnect.Stop()
+	}
+	if entry.source.Truthy() {
+		entry.source.Call(dom.JS_CLOSE)
+		entry.releaseListeners()
+		entry.source = js.Undefined()
+	}
+	entry.status = SOCKET_CLOSED
+}
+
+// Removes the listeners of the EventSource and releases their functions.
+func (entry *eventSourceEntry) releaseListeners() {
+	for event, listener := range entry.listeners {
+		entry.source.Call(dom.JS_REMOVE_EVENT_LISTENER, event, listener)
+		listener.Release()
+	}
+	entry.listeners = nil
+}
@@ -0,0 +1,214 @@
+package gooroo
+
+import (
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// Event wraps a JavaScript event with Go fields and methods. The raw event remains accessible
+// through the JsValue field.
+type Event struct {
+	JsValue       js.Value
+	Type          string
+	currentTarget js.Value
+}
+
+// MouseEvent wraps a JavaScript event emitted by a pointing device (click, mouseenter ...).
+type MouseEvent struct {
+	Event
+	ClientX  float64
+	ClientY  float64
+	PageX    float64
+	PageY    float64
+	OffsetX  float64
+	OffsetY  float64
+	Button   int
+	Buttons  int
+	AltKey   bool
+	CtrlKey  bool
+	ShiftKey bool
+	MetaKey  bool
+}
+
+// KeyboardEvent wraps a JavaScript event emitted by the keyboard (keydown, keyup).
+type KeyboardEvent struct {
+	Event
+	Key      string
+	Code     string
+	Repeat   bool
+	AltKey   bool
+	CtrlKey  bool
+	ShiftKey bool
+	MetaKey  bool
+}
+
+// InputEvent wraps a JavaScript event emitted when the value of an element changes (input).
+type InputEvent struct {
+	Event
+	Data      string
+	InputType string
+}
+
+// SubmitEvent wraps a JavaScript event emitted when a form is submitted.
+type SubmitEvent struct {
+	Event
+	Submitter js.Value
+}
+
+// DragEvent wraps a JavaScript event emitted by a drag and drop interaction.
+type DragEvent struct {
+	MouseEvent
+	DataTransfer js.Value
+}
+
+// Wraps the JavaScript event passed in parameter.
+func NewEvent(e js.Value) Event {
+	currentTarget := e.Get(dom.JS_CURRENT_TARGET)
+	if !delegatedTarget.IsUndefined() {
+		// the listener is on the root of the application, not on the bound element
+		currentTarget = delegatedTarget
+	}
+	return Event{e, e.Get(dom.JS_TYPE).String(), currentTarget}
+}
+
+// Wraps the JavaScript mouse event passed in parameter.
+func NewMouseEvent(e js.Value) MouseEvent {
+	return MouseEvent{
+		NewEvent(e),
+		e.Get(dom.JS_CLIENT_X).Float(),
+		e.Get(dom.JS_CLIENT_Y).Float(),
+		e.Get(dom.JS_PAGE_X).Float(),
+		e.Get(dom.JS_PAGE_Y).Float(),
+		e.Get(dom.JS_OFFSET_X).Float(),
+		e.Get(dom.JS_OFFSET_Y).Float(),
+		e.Get(dom.JS_BUTTON).Int(),
+		e.Get(dom.JS_BUTTONS).Int(),
+		e.Get(dom.JS_ALT_KEY).Bool(),
+		e.Get(dom.JS_CTRL_KEY).Bool(),
+		e.Get(dom.JS_SHIFT_KEY).Bool(),
+		e.Get(dom.JS_META_KEY).Bool(),
+	}
+}
+
+// Wraps the JavaScript keyboard event passed in parameter.
+func NewKeyboardEvent(e js.Value) KeyboardEvent {
+	return KeyboardEvent{
+		NewEvent(e),
+		e.Get(dom.JS_KEY).String(),
+		e.Get(dom.JS_CODE).String(),
+		e.Get(dom.JS_REPEAT).Bool(),
+		e.Get(dom.JS_ALT_KEY).Bool(),
+		e.Get(dom.JS_CTRL_KEY).Bool(),
+		e.Get(dom.JS_SHIFT_KEY).Bool(),
+		e.Get(dom.JS_META_KEY).Bool(),
+	}
+}
+
+// Wraps the JavaScript input event passed in parameter.
+func NewInputEvent(e js.Value) InputEvent {
+	input := InputEvent{Event: NewEvent(e)}
+	// 'change' events have neither data nor inputType
+	if data := e.Get(dom.JS_DATA); data.Type() == js.TypeString {
+		input.Data = data.String()
+	}
+	if inputType := e.Get(dom.JS_INPUT_TYPE); inputType.Type() == js.TypeString {
+		input.InputType = inputType.String()
+	}
+	return input
+}
+
+// Wraps the JavaScript submit event passed in parameter.
+func NewSubmitEvent(e js.Value) SubmitEvent {
+	return SubmitEvent{NewEvent(e), e.Get(dom.JS_SUBMITTER)}
+}
+
+// Wraps the JavaScript drag event passed in parameter.
+func NewDragEvent(e js.Value) DragEvent {
+	return DragEvent{NewMouseEvent(e), e.Get(dom.JS_DATA_TRANSFER)}
+}
+
+// Cancels the default action of the browser for the event.
+func (e Event) PreventDefault() {
+	e.JsValue.Call(dom.JS_PREVENT_DEFAULT)
+}
+
+// Prevents the event from propagating to the parent elements.
+func (e Event) StopPropagation() {
+	e.JsValue.Call(dom.JS_STOP_PROPAGATION)
+}
+
+// Prevents the event from propagating, including to the other listeners of the element.
+func (e Event) StopImmediatePropagation() {
+	e.JsValue.Call(dom.JS_STOP_IMMEDIATE_PROPAGATION)
+}
+
+// Returns the element that emitted the event.
+func (e Event) Target() js.Value {
+	return e.JsValue.Get(dom.JS_TARGET)
+}
+
+// Returns the element the binding is attached to.
+func (e Event) CurrentTarget() js.Value {
+	return e.currentTarget
+}
+
+// Returns the value of the element that emitted the event, as for an input.
+func (e Event) TargetValue() string {
+	value := e.Target().Get(dom.JS_VALUE)
+	if value.IsUndefined() || value.IsNull() {
+		return ""
+	}
+	return value.String()
+}
+
+// Returns the value of a field of the submitted form, by its name.
+func (e SubmitEvent) FormValue(name string) string {
+	value := js.Global().Get(dom.JS_FORM_DATA).New(e.Target()).Call(dom.JS_GET, name)
+	if value.IsNull() {
+		return ""
+	}
+	return value.String()
+}
+
+// Returns the data of the drag operation for the format passed in parameter ("text/plain" ...).
+func (e DragEvent) GetData(format string) string {
+	return e.DataTransfer.Call(dom.JS_GET_DATA, format).String()
+}
+
+// Sets the data of the drag operation for the format passed in parameter ("text/plain" ...).
+func (e DragEvent) SetData(format string, data string) {
+	e.DataTransfer.Call(dom.JS_SET_DATA, format, data)
+}
+
+// EventHandlers
+
+// Adapts a handler of Event to the callbacks of the bindings.
+func EventHandler(handler func(Event)) func(js.Value) {
+	return func(e js.Value) { handler(NewEvent(e)) }
+}
+
+// Adapts a handler of MouseEvent to the callbacks of the bindings.
+func MouseHandler(handler func(MouseEvent)) func(js.Value) {
+	return func(e js.Value) { handler(NewMouseEvent(e)) }
+}
+
+// Adapts a handler of KeyboardEvent to the callbacks of the bindings.
+func KeyboardHandler(handler func(KeyboardEvent)) func(js.Value) {
+	return func(e js.Value) { handler(NewKeyboardEvent(e)) }
+}
+
+// Adapts a handler of InputEvent to the callbacks of the bindings.
+func InputHandler(handler func(InputEvent)) func(js.Value) {
+	return func(e js.Value) { handler(NewInputEvent(e)) }
+}
+
+// Adapts a handler of SubmitEvent to the callbacks of the bindings.
+func SubmitHandler(handler func(SubmitEvent)) func(js.Value) {
+	return func(e js.Value) { handler(NewSubmitEvent(e)) }
+}
+
+// Adapts a handler of DragEvent to the callbacks of the bindings.
+func DragHandler(handler func(DragEvent)) func(js.Value) {
+	return func(e js.Value) { handler(NewDragEvent(e)) }
+}
@@ -0,0 +1,153 @@
+package gooroo
+
+import (
+	"errors"
+	"runtime"
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// File represents a file selected in a file input.
+type File struct {
+	JsValue      js.Value
+	Name         string
+	Size         int64
+	Type         string
+	LastModified int64
+}
+
+// Returns the File wrapping the javascript File passed in parameter.
+func NewFile(value js.Value) File {
+	return File{
+		value,
+		value.Get(dom.JS_NAME).String(),
+		int64(value.Get(dom.JS_SIZE).Float()),
+		value.Get(dom.JS_TYPE).String(),
+		int64(value.Get(dom.JS_LAST_MODIFIED).Float()),
+	}
+}
+
+// Reads the contents of the file asynchronously through a FileReader, and calls the callback with
+// them once they are loaded, or with the error of the reader.
+func (f File) Read(callback func(content []byte, err error)) {
+	reader := js.Global().Get(dom.JS_FILE_READER).New()
+	var onLoad, onError js.Func
+	release := func() {
+		onLoad.Release()
+		onError.Release()
+	}
+	onLoad = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		release()
+		array := js.Global().Get(dom.JS_UINT8_ARRAY).New(reader.Get(dom.JS_RESULT))
+		content := make([]byte, array.Length())
+		js.CopyBytesToGo(content, array)
+		callback(content, nil)
+		return nil
+	})
+	onError = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		release()
+		callback(nil, errors.New(reader.Get(dom.JS_ERROR).Get(dom.JS_MESSAGE).String()))
+		return nil
+	})
+	reader.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_LOAD, onLoad)
+	reader.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_ERROR, onError)
+	reader.Call(dom.JS_READ_AS_ARRAY_BUFFER, f.JsValue)
+}
+
+// Declare a two-way binding between the selected files of a file input and a list of Files.
+// Setting an empty list clears the input.
+func BindFiles[P *[]File | *any](files P, params ...BindingParam) DomComponent {
+	_, file, no, _ := runtime.Caller(1)
+	return bindTwoWay(utils.CallerToKey(file, no), typedDomValue(any(files),
+		func(target js.Value) []File {
+			selected := target.Get(dom.JS_FILES)
+			files := make([]File, selected.Length())
+			for i := range files {
+				files[i] = NewFile(selected.Index(i))
+			}
+			return files
+		},
+		func(elem js.Value, files []File) {
+			if len(files) == 0 {
+				elem.Set(dom.JS_VALUE, "")
+			}
+		},
+	), params...)
+}
+
+// UploadProgress describes the state of an upload started by Upload.
+type UploadProgress struct {
+	Loaded   int64
+	Total    int64
+	Done     bool
+	Status   int
+	Response string
+	Err      error
+}
+
+// Returns the percentage of the bytes sent, or 0 if the total is unknown.
+func (p UploadProgress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Loaded) * 100 / float64(p.Total)
+}
+
+// Uploads files in a multipart/form-data POST request through an XMLHttpRequest, each one under
+// the field passed in parameter. The onProgress function is called while the files are sent,
+// then once the request is done (with its status and response) or has failed. Typically it
+// calls the setter of a UseState. The returned function aborts the upload.
+func Upload(url string, field string, files []File, onProgress func(progress UploadProgress)) (abort func()) {
+	request := js.Global().Get(dom.JS_XML_HTTP_REQUEST).New()
+	body := js.Global().Get(dom.JS_FORM_DATA).New()
+	for _, file := range files {
+		body.Call(dom.JS_APPEND, field, file.JsValue, file.Name)
+	}
+	progress := UploadProgress{}
+	var onSend, onLoad, onError, onAbort js.Func
+	release := func() {
+		onSend.Release()
+		onLoad.Release()
+		onError.Release()
+		onAbort.Release()
+	}
+	onSend = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		progress.Loaded = int64(args[0].Get(dom.JS_LOADED).Float())
+		if args[0].Get(dom.JS_LENGTH_COMPUTABLE).Bool() {
+			progress.Total = int64(args[0].Get(dom.JS_TOTAL).Float())
+		}
+		onProgress(progress)
+		return nil
+	})
+	onLoad = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		release()
+		progress.Done = true
+		progress.Status = request.Get(dom.JS_STATUS).Int()
+		progress.Response = request.Get(dom.JS_RESPONSE_TEXT).String()
+		onProgress(progress)
+		return nil
+	})
+	onError = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		release()
+		progress.Done = true
+		progress.Err = errors.New("upload failed")
+		onProgress(progress)
+		return nil
+	})
+	onAbort = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		release()
+		progress.Done = true
+		progress.Err = errors.New("upload aborted")
+		onProgress(progress)
+		return nil
+	})
+	request.Get(dom.JS_UPLOAD).Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_PROGRESS, onSend)
+	request.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_LOAD, onLoad)
+	request.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_ERROR, onError)
+	request.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_ABORT, onAbort)
+	request.Call(dom.JS_OPEN, dom.HTTP_METHOD_POST, url)
+	request.Call(dom.JS_SEND, body)
+	return func() { request.Call(dom.JS_ABORT) }
+}
@@ -0,0 +1,444 @@
+// The forms package binds the fields of a Go struct to the inputs of a form, with a per-field
+// validation declared by struct tags, the tracking of the touched and dirty fields, and
+// a submission triggered only when the form is valid.
+//
+//	type Signup struct {
+//		Email string `form:"email" validate:"required,email,max=120"`
+//		Age   int    `form:"age" validate:"min=18"`
+//	}
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo"
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Struct tags declaring the name and the validation of the fields.
+const (
+	tagForm           = "form"
+	tagValidate       = "validate"
+	tagSeparator      = ","
+	tagParamSeparator = "="
+	tagIgnore         = "-"
+)
+
+// Rules usable in the validation tags.
+const (
+	ruleRequired = "required"
+	ruleEmail    = "email"
+	ruleMin      = "min"
+	ruleMax      = "max"
+	ruleOneOf    = "oneof"
+)
+
+// Prefix of the keys of the form states in the store.
+const storeKeyPrefix = "forms:"
+
+// Field describes a field of the struct bound to the form.
+type field struct {
+	name     string
+	index    int
+	kind     reflect.Kind
+	validate string
+}
+
+// FormState retains the state of a form across the renderings: the raw values of the inputs
+// (a string, or a bool for a checkbox), the errors, the touched fields and the state of an
+// asynchronous submission.
+type formState struct {
+	raw          map[string]*any
+	errors       map[string]string
+	touched      map[string]bool
+	submitted    bool
+	submitting   bool
+	succeeded    bool
+	err          error
+	serverErrors FieldErrors
+}
+
+// FieldErrors maps the name of the fields to their error message. Returned (or wrapped) by the
+// submit function of UseAsyncForm, it displays the errors on the matching inputs. It can be
+// decoded directly from a JSON object such as {"email": "Already used"}.
+type FieldErrors map[string]string
+
+// Returns the messages of the fields, sorted by name.
+func (e FieldErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	messages := make([]string, len(names))
+	for i, name := range names {
+		messages[i] = fmt.Sprintf("%s: %s", name, e[name])
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Form binds a struct of type T to the inputs of a form.
+type Form[T any] struct {
+	initial  T
+	fields   []field
+	state    *formState
+	onSubmit func(values T)
+	commit   func()
+	update   func(update func())
+}
+
+// Returns a form bound to a struct, initialized with the values passed in parameter.
+// The onSubmit function is called with the values of the form when it is submitted,
+// only if all its fields are valid. It may be nil, to only validate the form.
+func UseForm[T any](initial T, onSubmit func(values T)) *Form[T] {
+	_, file, no, _ := runtime.Caller(1)
+	return useForm(utils.CallerToKey(file, no), initial, onSubmit)
+}
+
+// Returns a form bound to a struct, whose valid submission runs the submit function in a goroutine.
+// Meanwhile the form is Submitting and its submit button is disabled. Then the form has Succeeded,
+// or exposes the returned error with SubmitError: if it is (or wraps) FieldErrors, its messages are
+// displayed on the matching inputs until their value changes.
+func UseAsyncForm[T any](initial T, submit func(values T) error) *Form[T] {
+	_, file, no, _ := runtime.Caller(1)
+	form := useForm[T](utils.CallerToKey(file, no), initial, nil)
+	form.onSubmit = func(values T) {
+		form.submitAsync(values, submit)
+	}
+	return form
+}
+
+// Returns the form stored under the key passed in parameter.
+func useForm[T any](key string, initial T, onSubmit func(values T)) *Form[T] {
+	fields := parseFields(reflect.TypeOf(initial))
+	value, set := gooroo.UseStateWithKey(storeKeyPrefix+key, newFormState(initial, fields))
+	state := (*value).(*formState)
+	return &Form[T]{initial, fields, state, onSubmit, func() { set(state) }, gooroo.Update}
+}
+
+// Lists the exported fields of a struct, named by their 'form' tag or by their Go name.
+func parseFields(t reflect.Type) []field {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("forms: a form must be bound to a struct, not %s", t))
+	}
+	fields := []field{}
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		name := structField.Tag.Get(tagForm)
+		if !structField.IsExported() || name == tagIgnore {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		fields = append(fields, field{name, i, structField.Type.Kind(), structField.Tag.Get(tagValidate)})
+	}
+	return fields
+}
+
+// Creates the state of a form, with the raw values of the struct passed in parameter.
+func newFormState(values any, fields []field) *formState {
+	state := &formState{raw: make(map[string]*any), errors: make(map[string]string), touched: make(map[string]bool)}
+	v := reflect.ValueOf(values)
+	for _, field := range fields {
+		var raw any
+		if field.kind == reflect.Bool {
+			raw = v.Field(field.index).Bool()
+		} else {
+			raw = fmt.Sprintf("%v", v.Field(field.index).Interface())
+		}
+		state.raw[field.name] = &raw
+	}
+	return state
+}
+
+// Converts the raw value of an input to the type of the field of the struct.
+func parseRaw(raw any, target reflect.Value) error {
+	if target.Kind() == reflect.Bool {
+		checked, _ := raw.(bool)
+		target.SetBool(checked)
+		return nil
+	}
+	str, _ := raw.(string)
+	trimmed := strings.TrimSpace(str)
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if trimmed == "" {
+			target.SetInt(0)
+			return nil
+		}
+		number, err := strconv.ParseInt(trimmed, 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("Must be an integer")
+		}
+		target.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if trimmed == "" {
+			target.SetUint(0)
+			return nil
+		}
+		number, err := strconv.ParseUint(trimmed, 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("Must be a positive integer")
+		}
+		target.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		if trimmed == "" {
+			target.SetFloat(0)
+			return nil
+		}
+		number, err := strconv.ParseFloat(trimmed, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("Must be a number")
+		}
+		target.SetFloat(number)
+	default:
+		return fmt.Errorf("Unsupported field of type %s", target.Type())
+	}
+	return nil
+}
+
+// Builds the struct from the raw values of the inputs, and returns it with the errors of the
+// fields that cannot be converted or are not valid.
+func (f *Form[T]) parse() (T, map[string]string) {
+	values := f.initial
+	v := reflect.ValueOf(&values).Elem()
+	errors := make(map[string]string)
+	for _, field := range f.fields {
+		if err := parseRaw(*f.state.raw[field.name], v.Field(field.index)); err != nil {
+			errors[field.name] = err.Error()
+		} else if message := validate(v.Field(field.index).Interface(), field.validate); message != "" {
+			errors[field.name] = message
+		}
+	}
+	return values, errors
+}
+
+// Validates all the fields of the form and records their errors.
+func (f *Form[T]) validate() {
+	_, f.state.errors = f.parse()
+}
+
+// Returns the raw value of a field, or panics if the field does not exist in the struct.
+func (f *Form[T]) raw(name string) *any {
+	raw, isPresent := f.state.raw[name]
+	if !isPresent {
+		panic(fmt.Sprintf("forms: unknown field %q", name))
+	}
+	return raw
+}
+
+// Returns the DomComponent params binding an element to a field: its name, the update of its
+// value, and the validation once the field has been touched.
+func (f *Form[T]) bind(name string, valueBinding gooroo.DomComponent) []gooroo.DomComponent {
+	params := []gooroo.DomComponent{
+		gooroo.Attr(dom.HTML_PARAM_NAME, name),
+		valueBinding,
+		gooroo.OnInput(gooroo.InputHandler(func(e gooroo.InputEvent) {
+			f.input(name, e.TargetValue())
+		})),
+		gooroo.OnBlur(func(_ js.Value) {
+			f.state.touched[name] = true
+			f.validate()
+			f.commit()
+		}),
+	}
+	if f.Error(name) != "" {
+		params = append(params, gooroo.Aria(dom.HTML_ARIA_INVALID, true))
+	}
+	return params
+}
+
+// Records the text of the input of a field, the value bound lazily being updated only on 'change'.
+// The form is validated and rendered again at each input only when the error of the field is
+// displayed, so that the rendering writes the text back into the input.
+func (f *Form[T]) input(name string, text string) {
+	if _, isText := (*f.raw(name)).(string); isText {
+		*f.raw(name) = text
+	}
+	_, hasServerError := f.state.serverErrors[name]
+	delete(f.state.serverErrors, name)
+	if f.Touched(name) || f.state.submitted || hasServerError {
+		f.validate()
+		f.commit()
+	}
+}
+
+// Returns the DomComponent param updating the string value of a field.
+func (f *Form[T]) bindValue(name string) gooroo.DomComponent {
+	return gooroo.OnChange(f.raw(name), gooroo.Lazy(), func(_ js.Value) {
+		f.validate()
+		f.commit()
+	})
+}
+
+// Runs the submit function of an asynchronous form in a goroutine, unless a submission is
+// already in progress, and records its result.
+func (f *Form[T]) submitAsync(values T, submit func(values T) error) {
+	if f.state.submitting {
+		return
+	}
+	f.state.submitting, f.state.succeeded, f.state.err = true, false, nil
+	go func() {
+		err := submit(values)
+		f.update(func() {
+			var fieldErrors FieldErrors
+			if errors.As(err, &fieldErrors) {
+				f.state.serverErrors = make(FieldErrors)
+				for name, message := range fieldErrors {
+					f.state.serverErrors[name] = message
+				}
+			}
+			f.state.submitting, f.state.succeeded, f.state.err = false, err == nil, err
+			f.commit()
+		})
+	}()
+}
+
+// Declare a <form> whose submission (prevented by default) calls the onSubmit function of the
+// form with its values, only if all the fields are valid. Otherwise the errors of all the fields
+// are displayed.
+func (f *Form[T]) Form(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	submit := gooroo.OnSubmit(gooroo.Prevent(), func(_ js.Value) {
+		if f.state.submitting {
+			return
+		}
+		f.state.submitted = true
+		f.state.serverErrors = nil
+		values, errors := f.parse()
+		f.state.errors = errors
+		if len(errors) == 0 && f.onSubmit != nil {
+			f.onSubmit(values)
+		}
+		f.commit()
+	})
+	return gooroo.Form(append([]gooroo.DomComponent{submit}, insiders...)...)
+}
+
+// Declare an <input> bound to a field of the form.
+func (f *Form[T]) Input(name string, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.Input(append(f.bind(name, f.bindValue(name)), insiders...)...)
+}
+
+// Declare a <textarea> bound to a field of the form.
+func (f *Form[T]) TextArea(name string, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.TextArea(append(f.bind(name, f.bindValue(name)), insiders...)...)
+}
+
+// Declare a <select> bound to a field of the form. Its options are passed in the insiders.
+func (f *Form[T]) Select(name string, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.Select(append(f.bind(name, f.bindValue(name)), insiders...)...)
+}
+
+// Declare a checkbox <input> bound to a boolean field of the form.
+func (f *Form[T]) Checkbox(name string, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	checked := gooroo.BindChecked(f.raw(name), func(_ js.Value) {
+		f.state.touched[name] = true
+		f.validate()
+		f.commit()
+	})
+	return gooroo.Input(append(append([]gooroo.DomComponent{gooroo.Type(dom.HTML_TYPE_CHECKBOX)}, f.bind(name, checked)...), insiders...)...)
+}
+
+// Declare a submit <button>, disabled while the form is submitting.
+func (f *Form[T]) SubmitButton(text string, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	params := []gooroo.DomComponent{gooroo.Type(dom.HTML_TYPE_SUBMIT), gooroo.Disabled(f.state.submitting)}
+	return gooroo.Button(text, append(params, insiders...)...)
+}
+
+// Returns the current values of the form. The fields that cannot be converted keep their
+// initial value.
+func (f *Form[T]) Values() T {
+	values, _ := f.parse()
+	return values
+}
+
+// Returns the error of a field, once it has been touched or the form has been submitted,
+// or the error returned for this field by the last asynchronous submission.
+func (f *Form[T]) Error(name string) string {
+	if message := f.state.errors[name]; message != "" && (f.Touched(name) || f.state.submitted) {
+		return message
+	}
+	return f.state.serverErrors[name]
+}
+
+// Returns the errors of all the fields, touched or not, including the errors returned by the
+// last asynchronous submission.
+func (f *Form[T]) Errors() map[string]string {
+	_, errors := f.parse()
+	for name, message := range f.state.serverErrors {
+		if _, isPresent := errors[name]; !isPresent {
+			errors[name] = message
+		}
+	}
+	return errors
+}
+
+// Returns true if all the fields of the form are valid.
+func (f *Form[T]) Valid() bool {
+	return len(f.Errors()) == 0
+}
+
+// Returns true if the field has lost the focus at least once.
+func (f *Form[T]) Touched(name string) bool {
+	return f.state.touched[name]
+}
+
+// Returns true if the value of the field differs from its initial value.
+func (f *Form[T]) Dirty(name string) bool {
+	initial := newFormState(f.initial, f.fields)
+	return *initial.raw[name] != *f.raw(name)
+}
+
+// Returns true if at least one field differs from its initial value.
+func (f *Form[T]) IsDirty() bool {
+	for _, field := range f.fields {
+		if f.Dirty(field.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the form has been submitted.
+func (f *Form[T]) Submitted() bool {
+	return f.state.submitted
+}
+
+// Returns true while the submit function of an asynchronous form is running.
+func (f *Form[T]) Submitting() bool {
+	return f.state.submitting
+}
+
+// Returns true if the last asynchronous submission returned no error.
+func (f *Form[T]) Succeeded() bool {
+	return f.state.succeeded
+}
+
+// Returns the error returned by the last asynchronous submission, or nil.
+func (f *Form[T]) SubmitError() error {
+	return f.state.err
+}
+
+// Resets the form to its initial values, and forgets its errors and touched fields.
+func (f *Form[T]) Reset() {
+	initial := newFormState(f.initial, f.fields)
+	for name, raw := range initial.raw {
+		*f.state.raw[name] = *raw
+	}
+	f.state.errors = make(map[string]string)
+	f.state.touched = make(map[string]bool)
+	f.state.submitted = false
+	f.state.succeeded, f.state.err, f.state.serverErrors = false, nil, nil
+	f.commit()
+}
@@ -0,0 +1,111 @@
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule checks the value of a field against the parameter of its validation tag ("120" for
+// "max=120"), and returns an error message if the value is not valid.
+type Rule func(value any, param string) string
+
+// Pattern of a valid email address.
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// List of the rules usable in the validation tags.
+var rules = map[string]Rule{
+	ruleRequired: func(value any, _ string) string {
+		if reflect.ValueOf(value).IsZero() {
+			return "This field is required"
+		}
+		return ""
+	},
+	ruleEmail: func(value any, _ string) string {
+		str, _ := value.(string)
+		if str != "" && !emailPattern.MatchString(str) {
+			return "Must be a valid email address"
+		}
+		return ""
+	},
+	ruleMin: func(value any, param string) string {
+		if size, isText := measure(value); size < parseParam(param) {
+			if isText {
+				return fmt.Sprintf("Must be at least %s characters", param)
+			}
+			return fmt.Sprintf("Must be at least %s", param)
+		}
+		return ""
+	},
+	ruleMax: func(value any, param string) string {
+		if size, isText := measure(value); size > parseParam(param) {
+			if isText {
+				return fmt.Sprintf("Must be at most %s characters", param)
+			}
+			return fmt.Sprintf("Must be at most %s", param)
+		}
+		return ""
+	},
+	ruleOneOf: func(value any, param string) string {
+		options := strings.Fields(param)
+		for _, option := range options {
+			if fmt.Sprintf("%v", value) == option {
+				return ""
+			}
+		}
+		return fmt.Sprintf("Must be one of: %s", strings.Join(options, ", "))
+	},
+}
+
+// Registers a rule usable in the validation tags under the name passed in parameter, or
+// replaces an existing one.
+func RegisterRule(name string, rule Rule) {
+	rules[name] = rule
+}
+
+// Validates a value with the rules of a validation tag ("required,email,max=120"), and returns
+// the message of the first rule not satisfied, or an empty string if the value is valid.
+func validate(value any, tag string) string {
+	for _, constraint := range strings.Split(tag, tagSeparator) {
+		name, param, _ := strings.Cut(strings.TrimSpace(constraint), tagParamSeparator)
+		if name == "" {
+			continue
+		}
+		rule, isPresent := rules[name]
+		if !isPresent {
+			panic(fmt.Sprintf("forms: unknown validation rule %q", name))
+		}
+		if message := rule(value, param); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// Returns the length of a text, or the value of a number, to compare it with min and max.
+func measure(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false
+	}
+	return 0, false
+}
+
+// Converts the parameter of a rule to a number.
+func parseParam(param string) float64 {
+	number, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("forms: invalid number %q in validation rule", param))
+	}
+	return number
+}
@@ -0,0 +1,155 @@
+// GOOS=js GOARCH=wasm go test ./forms
+
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type test struct {
+	name     string
+	function func(t *testing.T)
+}
+
+type signup struct {
+	Email   string `form:"email" validate:"required,email,max=20"`
+	Age     int    `form:"age" validate:"min=18"`
+	Plan    string `validate:"oneof=free pro"`
+	Terms   bool   `form:"terms" validate:"required"`
+	Ignored string `form:"-"`
+}
+
+var tests = []test{
+	{
+		"Validation rules",
+		func(t *testing.T) {
+			cases := []struct {
+				value   any
+				tag     string
+				isValid bool
+			}{
+				{"", "required", false},
+				{"a", "required", true},
+				{false, "required", false},
+				{0, "required", false},
+				{"", "email", true},
+				{"me@example.com", "email", true},
+				{"me@example", "email", false},
+				{"héllo", "max=5", true},
+				{"héllo!", "max=5", false},
+				{"ab", "min=3", false},
+				{17, "min=18", false},
+				{18.5, "min=18,max=20", true},
+				{"pro", "oneof=free pro", true},
+				{"team", "oneof=free pro", false},
+				{"", "required,email", false},
+			}
+			for _, c := range cases {
+				if isValid := validate(c.value, c.tag) == ""; isValid != c.isValid {
+					t.Errorf("validate(%v, %q) valid = %t, expected %t", c.value, c.tag, isValid, c.isValid)
+				}
+			}
+		},
+	},
+	{
+		"Custom rule",
+		func(t *testing.T) {
+			RegisterRule("even", func(value any, _ string) string {
+				if value.(int)%2 != 0 {
+					return "Must be even"
+				}
+				return ""
+			})
+			if validate(3, "even") != "Must be even" || validate(4, "even") != "" {
+				t.Error("Custom rule not applied")
+			}
+		},
+	},
+	{
+		"Struct binding",
+		func(t *testing.T) {
+			fields := parseFields(reflect.TypeOf(signup{}))
+			names := []string{}
+			for _, field := range fields {
+				names = append(names, field.name)
+			}
+			if len(names) != 4 || names[0] != "email" || names[2] != "Plan" {
+				t.Errorf("Unexpected fields %v", names)
+			}
+			form := &Form[signup]{signup{Email: "me@example.com", Age: 30, Plan: "free"}, fields, nil, nil, func() {}, func(update func()) { update() }}
+			form.state = newFormState(form.initial, fields)
+			*form.state.raw["age"] = "twelve"
+			*form.state.raw["Plan"] = "team"
+			values, errors := form.parse()
+			if errors["age"] != "Must be an integer" || errors["Plan"] == "" || errors["terms"] == "" || errors["email"] != "" {
+				t.Errorf("Unexpected errors %v", errors)
+			}
+			if values.Email != "me@example.com" || values.Age != 30 {
+				t.Error("Values not parsed from the raw inputs")
+			}
+			if !form.Dirty("age") || form.Dirty("email") {
+				t.Error("Dirty fields not detected")
+			}
+			*form.state.raw["age"] = " 42 "
+			*form.state.raw["Plan"] = "pro"
+			*form.state.raw["terms"] = true
+			if values, errors := form.parse(); len(errors) != 0 || values.Age != 42 || !values.Terms {
+				t.Errorf("Unexpected errors %v", errors)
+			}
+		},
+	},
+	{
+		"Typing into a touched field",
+		func(t *testing.T) {
+			commits := 0
+			form := &Form[signup]{signup{}, parseFields(reflect.TypeOf(signup{})), nil, nil, func() { commits++ }, func(update func()) { update() }}
+			form.state = newFormState(form.initial, form.fields)
+			form.input("email", "me@")
+			if *form.raw("email") != "me@" || commits != 0 {
+				t.Error("Untouched field not updated lazily")
+			}
+			form.state.touched["email"] = true
+			form.validate()
+			form.input("email", "me@example")
+			if *form.raw("email") != "me@example" || form.Error("email") == "" || commits != 1 {
+				t.Errorf("Touched field not revalidated with the typed text: %v", form.Error("email"))
+			}
+			form.input("email", "me@example.com")
+			if *form.raw("email") != "me@example.com" || form.Error("email") != "" {
+				t.Errorf("Error does not follow the typed text: %v", form.Error("email"))
+			}
+		},
+	},
+	{
+		"Async submission",
+		func(t *testing.T) {
+			committed := make(chan bool, 1)
+			form := &Form[signup]{signup{}, parseFields(reflect.TypeOf(signup{})), nil, nil, func() { committed <- true }, func(update func()) { update() }}
+			form.state = newFormState(form.initial, form.fields)
+			release := make(chan bool)
+			form.submitAsync(signup{}, func(values signup) error {
+				<-release
+				return fmt.Errorf("rejected: %w", FieldErrors{"email": "Already used"})
+			})
+			if !form.Submitting() {
+				t.Error("Form not submitting")
+			}
+			release <- true
+			<-committed
+			if form.Submitting() || form.Succeeded() || form.SubmitError() == nil {
+				t.Error("Submission state not updated")
+			}
+			if form.Error("email") != "Already used" || form.Valid() {
+				t.Error("Server error not mapped on the field")
+			}
+		},
+	},
+}
+
+func Test_All(t *testing.T) {
+	for _, test := range tests {
+		t.Run(test.name, test.function)
+	}
+}
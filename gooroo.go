@@ -6,11 +6,15 @@
 // javascript independent web library.
 package gooroo
 
+//go:generate go run ./dom/gen
+
 import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"syscall/js"
+	"time"
 
 	"github.com/Matbabs/Gooroo/dom"
 	"github.com/Matbabs/Gooroo/utils"
@@ -26,8 +30,33 @@ type DomComponent func() string
 // All binding is reapplied during rendering.
 type domBinding struct {
 	event    string
-	callback js.Func
-	value    *any
+	callback func(js.Value) bool
+	value    *domValue
+	modifier EventModifier
+}
+
+// DomValue retains the two-way link between a Go variable and the bound elements: how to read the
+// variable from the target of an event, how to write it back into the elements after the
+// rendering, and how to notify its change.
+type domValue struct {
+	read   func(target js.Value)
+	write  func(elem js.Value)
+	commit func()
+}
+
+// Throttle retains the last execution of a throttled DomBinding, and the execution postponed
+// to the end of the current interval, if any.
+type throttle struct {
+	last    time.Time
+	pending func()
+}
+
+// DomProperty retains a DOM property to apply on the element matching the selector, once the
+// html has been rendered (the 'checked' property of an input for example).
+type domProperty struct {
+	selector string
+	name     string
+	value    any
 }
 
 // DomStore allows to keep the state of change of a value in the store.
@@ -47,14 +76,50 @@ var (
 	// List of paths to add Js scripts already imported into the website.
 	scripts = []string{}
 
-	// Communication channel that generates a new rendering for each message sent within it.
+	// Communication channel that wakes up the render loop for each message sent within it.
 	state = make(chan bool, 1)
 
+	// Set to 1 when a new rendering is requested, and reset by the render loop.
+	renderRequested int32
+
+	// Set to 1 once the render loop is started, while it applies the updates, and while it renders
+	// the application.
+	looping, applying, rendering int32
+
+	// Updates of the application state requested from any goroutine, applied by the render
+	// loop before the next rendering.
+	updates = utils.Queue{}
+
 	// List of DomBindings registered for the application rendering.
 	bindings = make(map[string][]domBinding)
 
-	// Store the last domComponent that have been focused
-	lastDomComponentFocused = ""
+	// Number of bindings declared at the same position in the code during the rendering, to
+	// identify separately the elements of a loop.
+	bindingOccurrences = make(map[string]int)
+
+	// Listeners of the root of the application, one per event type, delegating the events
+	// to the DomBindings of their targets, in the capture and in the bubbling phases.
+	listeners = make(map[string]js.Func)
+
+	// Element whose DomBindings are being triggered by a delegated event.
+	delegatedTarget js.Value
+
+	// DomBindings declared with Once() that have been triggered, kept across the renderings
+	// as long as they are rendered.
+	firedOnce = make(map[string]bool)
+
+	// Timers of the debounced DomBindings, kept across the renderings.
+	debounces = make(map[string]*time.Timer)
+
+	// State of the throttled DomBindings, kept across the renderings.
+	throttles = make(map[string]*throttle)
+
+	// List of DOM properties to patch on the elements after the rendering.
+	properties = []domProperty{}
+
+	// Set of the elements that have been scrolled, whose scroll offsets are kept across
+	// the renderings.
+	scrolledElements js.Value
 
 	// Store of local variables recorded in the application state.
 	store = make(map[string]*domStore)
@@ -90,11 +155,19 @@ func Js(filepath string) {
 }
 
 // Triggers a rendering of the DOM, of all the DomComponents declared in parameters.
+// The DomComponents are appended directly to the <body>, without any wrapper element.
 func Html(domComponents ...DomComponent) {
-	for i := range domComponents {
-		elem := document.Call(dom.JS_CREATE_ELEMENT, dom.HTML_DIV)
-		document.Get(dom.HTML_BODY).Call(dom.JS_APPEND_CHILD, elem)
-		elem.Set(dom.JS_INNER_HTML, domComponents[i]())
+	appendHtml(document.Get(dom.HTML_BODY), Fragment(domComponents...)())
+}
+
+// Parses the html string in the namespace of the parent element and appends the resulting nodes
+// to it. The parsing context is created with createElementNS, so that the content patched inside
+// an <svg> (or a <math>) stays in the SVG (or MathML) namespace, even without its root element.
+func appendHtml(parent js.Value, htmlStr string) {
+	context := document.Call(dom.JS_CREATE_ELEMENT_NS, parent.Get(dom.JS_NAMESPACE_URI), parent.Get(dom.JS_LOCAL_NAME))
+	context.Set(dom.JS_INNER_HTML, htmlStr)
+	for context.Get(dom.JS_FIRST_CHILD).Truthy() {
+		parent.Call(dom.JS_APPEND_CHILD, context.Get(dom.JS_FIRST_CHILD))
 	}
 }
 
@@ -111,62 +184,222 @@ func clearContext() {
 	document.Get(dom.HTML_BODY).Set(dom.JS_INNER_HTML, "")
 }
 
-// Create a functional DomBinding set on its parameters.
-func generateBinding(id string, event string, value *any, callbacks ...func(js.Value)) domBinding {
+// Create a functional DomBinding set on its parameters. The callbacks are triggered for the
+// event of the binding, if it passes the filters of the modifier. If a value is bound, it is
+// updated with the value of the target before. The function of the binding returns false if the
+// event has been filtered out.
+// The bindings of a value on the same element (on 'input' and 'change') are scheduled together,
+// so that an edit is committed only once.
+func generateBinding(selector string, event string, value *domValue, modifier EventModifier, callbacks ...func(js.Value)) domBinding {
+	key := selector + event
+	if value != nil {
+		key = selector
+	}
+	if modifier.once {
+		key := selector + event
+		useResource(ONCE_RESOURCE_PREFIX+key, func() { delete(firedOnce, key) })
+	}
 	return domBinding{
 		event,
-		js.FuncOf(
-			func(_ js.Value, args []js.Value) any {
-				needToChanged := false
-				switch event {
-				case dom.JS_EVENT_CHANGE, dom.JS_EVENT_KEYUP, dom.JS_EVENT_KEYDOWN:
-					// change value when event is emitted before callbacks calls
-					*value = args[0].Get(dom.JS_TARGET).Get(dom.JS_VALUE).String()
-					needToChanged = true
-				case dom.JS_EVENT_FOCUS:
-					// set last focused
-					lastDomComponentFocused = id
+		func(e js.Value) bool {
+			for _, filter := range modifier.filters {
+				if !filter(e) {
+					return false
 				}
-				if event == dom.JS_EVENT_CHANGE || event == dom.JS_EVENT_CLICK {
-					for i := range callbacks {
-						callbacks[i](args[0])
-					}
+			}
+			if modifier.prevent {
+				e.Call(dom.JS_PREVENT_DEFAULT)
+			}
+			if modifier.stop && modifier.capture {
+				// the other bindings are stopped by the dispatch, the event only in the capture phase
+				e.Call(dom.JS_STOP_PROPAGATION)
+			}
+			needToChanged := false
+			if value != nil {
+				// change value when event is emitted before callbacks calls
+				value.read(e.Get(dom.JS_TARGET))
+				needToChanged = true
+			}
+			schedule(key, modifier, func() {
+				for i := range callbacks {
+					callbacks[i](e)
 				}
 				if needToChanged {
 					// force state change but keep updated value
-					setHasChanged(value, *value)
+					value.commit()
 				}
-				return nil
-			},
-		),
+			})
+			return true
+		},
 		value,
+		modifier,
 	}
 }
 
-// Applies all the bindings to the DOM elements concerned.
+// Runs the function of a DomBinding immediately, or debounced or throttled according to the
+// modifier. The key identifies the DomBinding across the renderings.
+func schedule(key string, modifier EventModifier, run func()) {
+	switch {
+	case modifier.debounce > 0:
+		if timer, isPresent := debounces[key]; isPresent {
+			timer.Stop()
+		}
+		debounces[key] = time.AfterFunc(modifier.debounce, func() {
+			Update(func() {
+				delete(debounces, key)
+				run()
+			})
+		})
+	case modifier.throttle > 0:
+		utils.MapInit(key, throttles, &throttle{})
+		t := throttles[key]
+		if t.pending != nil {
+			// the last event of the interval will be run at its end
+			t.pending = run
+			return
+		}
+		if elapsed := time.Since(t.last); elapsed < modifier.throttle {
+			t.pending = run
+			time.AfterFunc(modifier.throttle-elapsed, func() {
+				Update(func() {
+					t.last = time.Now()
+					pending := t.pending
+					t.pending = nil
+					pending()
+				})
+			})
+			return
+		}
+		t.last = time.Now()
+		run()
+	default:
+		run()
+	}
+}
+
+// Applies all the bindings to the DOM elements concerned. The events are not listened on the
+// elements but delegated from the root of the application, so only the bound values need
+// to be restored on the elements.
 func setBindings() {
-	for id := range bindings {
-		elem := document.Call(dom.JS_GET_ELEMENT_BY_CLASSNAME, id).Index(0)
-		for i := range bindings[id] {
-			// add event listener
-			elem.Call(dom.JS_ADD_EVENT_LISTENER, bindings[id][i].event, bindings[id][i].callback)
-			switch bindings[id][i].event {
-			case dom.JS_EVENT_CHANGE:
+	for selector := range bindings {
+		for i := range bindings[selector] {
+			listen(bindings[selector][i].event, bindings[selector][i].modifier.passive)
+			if bindings[selector][i].value != nil && bindings[selector][i].event == dom.JS_EVENT_CHANGE {
 				// add actual value if defined in input
-				elem.Set(dom.JS_VALUE, *(bindings[id][i].value))
-			case dom.JS_EVENT_FOCUS:
-				// reset focus to input if last focused
-				if id == lastDomComponentFocused {
-					elem.Call(dom.JS_EVENT_FOCUS)
+				elems := document.Call(dom.JS_QUERY_SELECTOR_ALL, selector)
+				for e := 0; e < elems.Length(); e++ {
+					bindings[selector][i].value.write(elems.Index(e))
+				}
+			}
+		}
+	}
+}
+
+// Adds to the root of the application the listener of an event type, if it is not listened yet.
+// The listener is registered in the capture phase, for the capture bindings and the events that do
+// not bubble (focus, mouseenter ...), and in the bubbling phase for the other bindings, so that the
+// target and its own listeners receive the event before them. Passive bindings have their own
+// passive listener.
+func listen(event string, passive bool) {
+	key := fmt.Sprintf("%s#%t", event, passive)
+	if _, isPresent := listeners[key]; isPresent {
+		return
+	}
+	listeners[key] = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		runOnLoop(func() { dispatch(args[0], passive) })
+		return nil
+	})
+	for _, capture := range []bool{true, false} {
+		document.Get(dom.HTML_BODY).Call(dom.JS_ADD_EVENT_LISTENER, event, listeners[key], map[string]any{
+			dom.JS_OPTION_CAPTURE: capture,
+			dom.JS_OPTION_PASSIVE: passive,
+		})
+	}
+}
+
+// Dispatches an event received by the root of the application to the DomBindings of the elements
+// between the root and its target: in the capture phase the capture bindings from the root to the
+// target, and in the bubbling phase the other bindings from the target to the root. The bindings of
+// the target of an event that does not bubble are triggered in the capture phase.
+func dispatch(e js.Value, passive bool) {
+	root := document.Get(dom.HTML_BODY)
+	path := []js.Value{}
+	for elem := e.Get(dom.JS_TARGET); elem.Truthy() && !elem.Equal(root); elem = elem.Get(dom.JS_PARENT_ELEMENT) {
+		path = append(path, elem)
+	}
+	if e.Get(dom.JS_EVENT_PHASE).Int() == dom.JS_EVENT_PHASE_CAPTURING {
+		for i := len(path) - 1; i >= 0; i-- {
+			if dispatchBindings(path[i], e, passive, true) {
+				return
+			}
+		}
+		if len(path) > 0 && !e.Get(dom.JS_BUBBLES).Bool() {
+			dispatchBindings(path[0], e, passive, false)
+		}
+		return
+	}
+	for i := range path {
+		if dispatchBindings(path[i], e, passive, false) {
+			return
+		}
+	}
+}
+
+// Triggers the DomBindings of an element matching the event, found through the keys listed in the
+// binding attributes of the element. Returns true if the dispatch of the event has been stopped, by
+// a binding with the Stop() modifier or by the propagation of the event.
+func dispatchBindings(elem js.Value, e js.Value, passive bool, capture bool) bool {
+	event := e.Get(dom.JS_TYPE).String()
+	attributes := elem.Get(dom.JS_ATTRIBUTES)
+	if !attributes.Truthy() {
+		return false
+	}
+	delegatedTarget = elem
+	defer func() { delegatedTarget = js.Undefined() }()
+	stopped := false
+	for a := 0; a < attributes.Length(); a++ {
+		name := attributes.Index(a).Get(dom.JS_NAME).String()
+		if !strings.HasPrefix(name, dom.HTML_PARAM_GOOROO+dom.JS_EVENT_PREFIX) {
+			continue
+		}
+		for _, key := range strings.Fields(attributes.Index(a).Get(dom.JS_VALUE).String()) {
+			selector := bindingSelector(name, key)
+			for i := range bindings[selector] {
+				binding := &bindings[selector][i]
+				if binding.event != event || binding.modifier.passive != passive || binding.modifier.capture != capture || firedOnce[selector+event] {
+					continue
+				}
+				if binding.modifier.once {
+					firedOnce[selector+event] = true
+				}
+				if binding.callback(e) && binding.modifier.stop {
+					stopped = true
 				}
 			}
 		}
 	}
+	return stopped || e.Get(dom.JS_CANCEL_BUBBLE).Bool()
 }
 
 // Deletes all the DomBindings stored locally.
 func unsetBindings() {
 	bindings = make(map[string][]domBinding)
+	bindingOccurrences = make(map[string]int)
+}
+
+// Applies all the DOM properties to the elements concerned.
+func setProperties() {
+	for _, property := range properties {
+		elem := document.Call(dom.JS_QUERY_SELECTOR, property.selector)
+		if !elem.IsNull() {
+			elem.Set(property.name, property.value)
+		}
+	}
+}
+
+// Deletes all the DOM properties stored locally.
+func unsetProperties() {
+	properties = []domProperty{}
 }
 
 // Change variable from store & updateState
@@ -203,37 +436,115 @@ func clearHasChange() {
 
 // Starts the library's renderer. Allows to re-trigger the renderings when the
 // state changes (with a UseSate variable for example), through the state channel.
+// The updates requested from other goroutines are applied before each rendering.
+// The focused element, its selection and the scroll offsets are preserved across the renderings.
 // Must take a lambda function func() containing the call to Html() as parameter
 // to execute a rendering context.
 func Render(context func()) {
+	trackScrolls()
 	updateState()
+	atomic.StoreInt32(&looping, 1)
 	for {
 		<-state
+		applyUpdates()
+		if atomic.SwapInt32(&renderRequested, 0) == 0 {
+			continue
+		}
+		atomic.StoreInt32(&rendering, 1)
+		view := saveView()
 		clearContext()
 		unsetBindings()
+		unsetProperties()
+		unsetSignals()
 		context()
+		releaseResources()
 		clearHasChange()
 		setBindings()
+		setProperties()
+		setSignals()
+		restoreView(view)
+		atomic.StoreInt32(&rendering, 0)
 	}
 }
 
+// Applies the pending updates on the render loop. The events they emit synchronously (a blur
+// caused by focus(), a click() ...) have their handlers run directly by runOnLoop, since the loop
+// is busy running the update that waits for them.
+func applyUpdates() {
+	atomic.StoreInt32(&applying, 1)
+	defer atomic.StoreInt32(&applying, 0)
+	updates.Drain()
+}
+
 // Called to trigger in parallel a message sending in the chan state and consequently
-// request the new rendering of the application.
+// request the new rendering of the application. If a rendering is already requested,
+// the changes are rendered with it.
 func updateState() {
-	state <- true
+	atomic.StoreInt32(&renderRequested, 1)
+	wakeUp()
+}
+
+// Wakes up the render loop to apply the pending updates, without requesting a new rendering.
+func wakeUp() {
+	select {
+	case state <- true:
+	default:
+	}
+}
+
+// Requests an update of the application state, applied by the render loop before the next rendering.
+// Allows the state to be updated safely from any goroutine, by running the update on the render loop.
+func Update(update func()) {
+	updates.Push(update)
+	updateState()
+}
+
+// Runs the function on the render loop, and waits for its execution. Allows the handlers of the DOM
+// events to update the state safely, while still being able to cancel their event. The events emitted
+// by the rendering itself (the blur of a removed element ...) are handled after it, and the
+// function is run directly if the render loop is not started or if it is applying an update.
+func runOnLoop(f func()) {
+	switch {
+	case atomic.LoadInt32(&looping) == 0, atomic.LoadInt32(&applying) == 1:
+		f()
+	case atomic.LoadInt32(&rendering) == 1:
+		enqueue(f)
+	default:
+		done := make(chan bool)
+		enqueue(func() {
+			defer close(done)
+			f()
+		})
+		<-done
+	}
+}
+
+// Same as Update(), but the application is rendered again only if the update requests it
+// (by changing a variable of the store for example).
+func enqueue(update func()) {
+	updates.Push(update)
+	wakeUp()
 }
 
 // Returns a stateful value, and a function to update it.
 // During the initial render, the returned state (state) is the same as the value
 // passed as the first argument (initialState).
 // The setState function is used to update the state. It accepts a new state value
-// and enqueues a re-render of the DOM.
+// and enqueues a re-render of the DOM. It can be called from any goroutine (after an HTTP
+// request, a timer ...): the new value is applied by the render loop before the re-render.
 func UseState(initialValue any) (actualValue *any, f func(setterValue any)) {
 	_, file, no, _ := runtime.Caller(1)
-	key := utils.CallerToKey(file, no)
+	return UseStateWithKey(utils.CallerToKey(file, no), initialValue)
+}
+
+// Same hook as UseState(), but the value is recorded in the store under the key passed in
+// parameter instead of the position of the caller. Allows the hooks of other packages to be
+// built on top of it, with a key derived from the position of their own caller.
+func UseStateWithKey(key string, initialValue any) (actualValue *any, f func(setterValue any)) {
 	utils.MapInit(key, store, &domStore{initialValue, false})
-	return &store[key].value, func(setVal any) {
-		setHasChanged(&store[key].value, setVal)
+	value := &store[key].value
+	return value, func(setVal any) {
+		Update(func() { setHasChanged(value, setVal) })
 	}
 }
 
@@ -278,14 +589,16 @@ func UseMemo(callback func() any, variables ...*any) any {
 func insertDomComponentParams(opener string, insiders ...DomComponent) (string, []DomComponent) {
 	var insidersWithoutParam []DomComponent
 	for _, insider := range insiders {
-		if strings.Contains(insider(), dom.ELEMENT_PARAM) {
+		// rendered once, since the params may register state for the rendering (DOM properties)
+		htmlStr := insider()
+		if strings.Contains(htmlStr, dom.ELEMENT_PARAM) {
 			split := (strings.Split(opener, dom.ELEMENT_PARAM_SPLIT))
-			opener = fmt.Sprintf("%s %s%s%s", split[0], strings.Split(insider(), dom.ELEMENT_PARAM)[1], dom.ELEMENT_PARAM_SPLIT, split[1])
+			opener = fmt.Sprintf("%s %s%s%s", split[0], strings.Split(htmlStr, dom.ELEMENT_PARAM)[1], dom.ELEMENT_PARAM_SPLIT, split[1])
 		} else {
-			insidersWithoutParam = append(insidersWithoutParam, insider)
+			insidersWithoutParam = append(insidersWithoutParam, func() string { return htmlStr })
 		}
 	}
-	return opener, insidersWithoutParam
+	return mergeBindingMarkers(opener), insidersWithoutParam
 }
 
 // Returns the html rendering of the DomComponent reproduced recursively with all its DomComponents insiders
@@ -301,14 +614,20 @@ func htmlDomComponent(opener string, closer string, insiders ...DomComponent) Do
 	return func() string { return fmt.Sprintf("%s%s", opener, closer) }
 }
 
-// Same function as htmlDomComponent() but only if the condition in parameter is valid.
+// Groups several DomComponents as siblings, without wrapping them in an html element.
+// Allows a component to return multiple roots, like the rows of a table or the items of a list.
+func Fragment(insiders ...DomComponent) DomComponent {
+	htmlStr := ""
+	for _, insider := range insiders {
+		htmlStr += insider()
+	}
+	return func() string { return htmlStr }
+}
+
+// Same function as Fragment() but only if the condition in parameter is valid.
 func If(condition bool, insiders ...DomComponent) DomComponent {
 	if condition {
-		htmlStr := ""
-		for _, insider := range insiders {
-			htmlStr += insider()
-		}
-		return func() string { return htmlStr }
+		return Fragment(insiders...)
 	}
 	return func() string { return "" }
 }
@@ -328,142 +647,25 @@ func For[T string | int | int32 | int64 | float32 | float64 | bool | any](elemen
 
 // DomComponents
 
-// Declare an html element with the <div> tag.
-func Div(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_DIV_OPENER, dom.HTML_DIV_CLOSER, insiders...)
-}
+// The constructors of the HTML elements (Div, P, Section, Input ...) are generated from
+// dom/elements.txt into elements.go.
 
-// Declare an html element with the <p> tag.
-func P[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_P_OPENER, textStr), dom.HTML_P_CLOSER, insiders...)
-}
-
-// Declare an html element with the <span> tag.
-func Span[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_SPAN_OPENER, textStr), dom.HTML_SPAN_CLOSER, insiders...)
-}
-
-// Declare an html element with the <ul> tag.
-func Ul(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_UL_OPENER, dom.HTML_UL_CLOSER, insiders...)
-}
-
-// Declare an html element with the <li> tag.
-func Li(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_LI_OPENER, dom.HTML_LI_CLOSER, insiders...)
-}
-
-// Declare an html element with the <table> tag.
-func Table(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_TABLE_OPENER, dom.HTML_TABLE_CLOSER, insiders...)
-}
-
-// Declare an html element with the <tr> tag.
-func Tr(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_TR_OPENER, dom.HTML_TR_CLOSER, insiders...)
-}
-
-// Declare an html element with the <th> tag.
-func Th(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_TH_OPENER, dom.HTML_TH_CLOSER, insiders...)
-}
-
-// Declare an html element with the <td> tag.
-func Td(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_TD_OPENER, dom.HTML_TD_CLOSER, insiders...)
-}
-
-// Declare an html element with the <h1> tag.
-func H1[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H1_OPENER, textStr), dom.HTML_H1_CLOSER, insiders...)
-}
-
-// Declare an html element with the <h2> tag.
-func H2[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H2_OPENER, textStr), dom.HTML_H2_CLOSER, insiders...)
-}
-
-// Declare an html element with the <h3> tag.
-func H3[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H3_OPENER, textStr), dom.HTML_H3_CLOSER, insiders...)
-}
-
-// Declare an html element with the <h4> tag.
-func H4[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_H4_OPENER, textStr), dom.HTML_H4_CLOSER, insiders...)
-}
-
-// Declare an html element with the <a> tag.
-func A[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_A_OPENER, textStr), dom.HTML_A_CLOSER, insiders...)
-}
-
-// Declare an html element with the <form> tag.
-func Form(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_FORM_OPENER, dom.HTML_FORM_CLOSER, insiders...)
-}
-
-// Declare an html element with the <textarea> tag.
-func TextArea(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_TEXTAREA_OPENER, dom.HTML_TEXTAREA_CLOSER, insiders...)
-}
-
-// Declare an html element with the <select> tag.
-func Select(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_SELECT_OPENER, dom.HTML_SELECT_CLOSER, insiders...)
-}
-
-// Declare an html element with the <option> tag.
-func Option[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
-	textStr := utils.AnyStr(text)
-	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_OPTION_OPENER, textStr), dom.HTML_OPTION_CLOSER, insiders...)
-}
-
-// Declare an html element with the <input> tag.
-func Input(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_INPUT_OPENER, "", insiders...)
+// Declare an html element with any tag, for the elements without a dedicated constructor
+// (custom elements for example). Void elements are rendered without closing tag, whatever the
+// case of their tag. The case is kept for the camelCase elements of SVG (linearGradient ...).
+func El(tag string, insiders ...DomComponent) DomComponent {
+	tag = utils.SanitizeName(tag)
+	if utils.Contains(dom.HTML_VOID_ELEMENTS, strings.ToLower(tag)) {
+		return htmlDomComponent(fmt.Sprintf("<%s>", tag), "", insiders...)
+	}
+	return htmlDomComponent(fmt.Sprintf("<%s>", tag), fmt.Sprintf("</%s>", tag), insiders...)
 }
 
-// Declare an html element with the <button> tag.
-func Button[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...DomComponent) DomComponent {
+// Declare a text, sanitized in the same way as the text of the html elements.
+func Text[T string | int | int32 | int64 | float32 | float64 | bool](text T) DomComponent {
 	textStr := utils.AnyStr(text)
 	sanitizeHtml(&textStr)
-	return htmlDomComponent(fmt.Sprintf("%s%s", dom.HTML_BUTTON_OPENER, textStr), dom.HTML_BUTTON_CLOSER, insiders...)
-}
-
-// Declare an html element with the <img> tag.
-func Img(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_IMG_OPENER, "", insiders...)
-}
-
-// Declare an html element with the <i> tag.
-func I(insiders ...DomComponent) DomComponent {
-	return htmlDomComponent(dom.HTML_I_OPENER, "", insiders...)
-}
-
-// Declare an html element with the <br> tag.
-func Br() DomComponent {
-	return htmlDomComponent(dom.HTML_BR_OPENER, "")
-}
-
-// Declare an html element with the <hr> tag.
-func Hr() DomComponent {
-	return htmlDomComponent(dom.HTML_HR_OPENER, "")
+	return func() string { return textStr }
 }
 
 // DomComponentsParams
@@ -526,6 +728,74 @@ func Title(title string) DomComponent {
 	return func() string { return fmt.Sprintf("%s%s'%s'", dom.ELEMENT_PARAM, dom.HTML_PARAM_TITLE, title) }
 }
 
+// Declare an attribute of an html element with any name, for the attributes without
+// a dedicated DomComponent.
+func Attr[T string | int | int32 | int64 | float32 | float64 | bool](name string, value T) DomComponent {
+	name = utils.SanitizeName(name)
+	valueStr := utils.AnyStr(value)
+	sanitizeHtml(&valueStr)
+	valueStr = strings.ReplaceAll(valueStr, "'", dom.HTML_QUOTE_ESCAPED)
+	return func() string { return fmt.Sprintf("%s%s='%s'", dom.ELEMENT_PARAM, name, valueStr) }
+}
+
+// Declare a custom data attribute of an html element with the value 'data-key='
+func Data[T string | int | int32 | int64 | float32 | float64 | bool](key string, value T) DomComponent {
+	return Attr(dom.HTML_PARAM_DATA+key, value)
+}
+
+// Declare an accessibility attribute of an html element with the value 'aria-key='
+func Aria[T string | int | int32 | int64 | float32 | float64 | bool](key string, value T) DomComponent {
+	return Attr(dom.HTML_PARAM_ARIA+key, value)
+}
+
+// DomComponentsParamsBoolean
+
+// Declare a boolean attribute of an html element, present only if the value is true.
+// The matching DOM property is also patched after the rendering, since the attribute only
+// reflects the initial state of the element (as 'checked' for an input). The property is
+// registered for the rendering in progress when the attribute is rendered.
+func booleanDomComponentParam(attribute string, property string, value bool) DomComponent {
+	if !value {
+		return func() string { return "" }
+	}
+	marker := fmt.Sprintf("%s%s", dom.HTML_PARAM_GOOROO, attribute)
+	return func() string {
+		id := len(properties)
+		properties = append(properties, domProperty{fmt.Sprintf("[%s='%d']", marker, id), property, value})
+		return fmt.Sprintf("%s%s %s='%d'", dom.ELEMENT_PARAM, attribute, marker, id)
+	}
+}
+
+// Declare the boolean attribute 'disabled' of an html element.
+func Disabled(disabled bool) DomComponent {
+	return booleanDomComponentParam(dom.HTML_PARAM_DISABLED, dom.JS_DISABLED, disabled)
+}
+
+// Declare the boolean attribute 'checked' of an html element.
+func Checked(checked bool) DomComponent {
+	return booleanDomComponentParam(dom.HTML_PARAM_CHECKED, dom.JS_CHECKED, checked)
+}
+
+// Declare the boolean attribute 'required' of an html element.
+func Required(required bool) DomComponent {
+	return booleanDomComponentParam(dom.HTML_PARAM_REQUIRED, dom.JS_REQUIRED, required)
+}
+
+// Declare the boolean attribute 'readonly' of an html element.
+func ReadOnly(readOnly bool) DomComponent {
+	return booleanDomComponentParam(dom.HTML_PARAM_READONLY, dom.JS_READ_ONLY, readOnly)
+}
+
+// Declare the boolean attribute 'hidden' of an html element.
+func Hidden(hidden bool) DomComponent {
+	return booleanDomComponentParam(dom.HTML_PARAM_HIDDEN, dom.JS_HIDDEN, hidden)
+}
+
+// Declare the boolean attribute 'selected' of an html element.
+func Selected(selected bool) DomComponent {
+	return booleanDomComponentParam(dom.HTML_PARAM_SELECTED, dom.JS_SELECTED, selected)
+}
+
 // DomComponentsParamsStructure
 
 // Declare une configuration CSS dans l'attribut d'un element html avec la valeur 'style=',
@@ -554,26 +824,3 @@ func GridLayout[T string | int](columns T, rows T, gap string) DomComponent {
 		dom.CSS_PARAM_GRID_ROWS, dom.CSS_PARAM_GRID_REPEAT_OPENER, rowsStr, dom.CSS_PARAM_GRID_REPEAT_CLOSER, dom.CSS_PARAM_GAP, gap)
 	return func() string { return fmt.Sprintf("%s%s'%s'", dom.ELEMENT_PARAM, dom.HTML_PARAM_STYLE, layout) }
 }
-
-// DomComponentsParamsBinding
-
-// Declare a binding on the event 'click' on the attached element to trigger
-// the function passed in parameter.
-func OnClick(callbacks ...func(js.Value)) DomComponent {
-	_, file, no, _ := runtime.Caller(1)
-	key := utils.CallerToKey(file, no)
-	bindings[key] = append(bindings[key], generateBinding(key, dom.JS_EVENT_CLICK, nil, callbacks...))
-	return func() string { return fmt.Sprintf("%s%s'%s'", dom.ELEMENT_PARAM, dom.HTML_PARAM_CLASSNAME, key) }
-}
-
-// Declare a binding on the event 'change' on the attached element to trigger
-// the function passed in parameter.
-func OnChange(value *any, callbacks ...func(js.Value)) DomComponent {
-	_, file, no, _ := runtime.Caller(1)
-	key := utils.CallerToKey(file, no)
-	bindings[key] = append(bindings[key], generateBinding(key, dom.JS_EVENT_CHANGE, value, callbacks...))
-	bindings[key] = append(bindings[key], generateBinding(key, dom.JS_EVENT_KEYUP, value, callbacks...))
-	bindings[key] = append(bindings[key], generateBinding(key, dom.JS_EVENT_KEYDOWN, value, callbacks...))
-	bindings[key] = append(bindings[key], generateBinding(key, dom.JS_EVENT_FOCUS, value, callbacks...))
-	return func() string { return fmt.Sprintf("%s%s'%s'", dom.ELEMENT_PARAM, dom.HTML_PARAM_CLASSNAME, key) }
-}
@@ -3,10 +3,14 @@
 package gooroo
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall/js"
 	"testing"
+	"time"
 
 	"github.com/Matbabs/Gooroo/dom"
 )
@@ -52,7 +56,7 @@ var tests = []test{
 					P(paragraph),
 				),
 			)
-			div := body.Get(dom.JS_CHILDREN).Get("1").Get(dom.JS_CHILDREN).Get("0")
+			div := body.Get(dom.JS_CHILDREN).Get("1")
 			h1 := div.Get(dom.JS_CHILDREN).Get("0").Get(dom.JS_INNER_HTML)
 			p := div.Get(dom.JS_CHILDREN).Get("1").Get(dom.JS_INNER_HTML)
 			if title != h1.String() || paragraph != p.String() {
@@ -79,6 +83,1022 @@ var tests = []test{
 			}
 		},
 	},
+	{
+		"Generated elements",
+		func(t *testing.T) {
+			html := Section(Ol(Li(Code("x"))), Br(), I())()
+			if html != "<section><ol><li><code>x</code></li></ol><br><i></i></section>" {
+				t.Errorf("Unexpected html rendering: %s", html)
+			}
+		},
+	},
+	{
+		"El",
+		func(t *testing.T) {
+			if html := El("my-element<", P("x"))(); html != "<my-element><p>x</p></my-element>" {
+				t.Errorf("Unexpected html rendering: %s", html)
+			}
+			if html := El("wbr")(); html != "<wbr>" {
+				t.Errorf("Void element has a closing tag: %s", html)
+			}
+			if html := El("BR")(); html != "<BR>" {
+				t.Errorf("Uppercase void element has a closing tag: %s", html)
+			}
+		},
+	},
+	{
+		"Attr",
+		func(t *testing.T) {
+			html := Div(Attr("role", "list"), Data("id", 42), Aria("expanded", true), Attr("title", "it's"))()
+			expected := "<div role='list' data-id='42' aria-expanded='true' title='it&#39;s'></div>"
+			if html != expected {
+				t.Errorf("Unexpected html rendering: %s", html)
+			}
+		},
+	},
+	{
+		"Boolean attributes",
+		func(t *testing.T) {
+			unsetProperties()
+			clearContext()
+			Html(Input(Type("checkbox"), Checked(true), Disabled(false)))
+			setProperties()
+			input := body.Call(dom.JS_QUERY_SELECTOR, "input")
+			if !input.Get(dom.JS_CHECKED).Bool() || input.Get(dom.JS_DISABLED).Bool() {
+				t.Error("DOM properties are not patched")
+			}
+			if !input.Call("hasAttribute", dom.HTML_PARAM_CHECKED).Bool() || input.Call("hasAttribute", dom.HTML_PARAM_DISABLED).Bool() {
+				t.Error("Boolean attributes are not rendered correctly")
+			}
+			if html := Input(Disabled(false))(); html != "<input>" {
+				t.Errorf("False boolean attribute is rendered: %s", html)
+			}
+			unsetProperties()
+			Checked(true)
+			if len(properties) != 0 {
+				t.Error("DOM property registered before the rendering of the attribute")
+			}
+		},
+	},
+	{
+		"appendHtml namespace",
+		func(t *testing.T) {
+			svg := document.Call(dom.JS_CREATE_ELEMENT_NS, dom.SVG_NAMESPACE, dom.SVG_SVG)
+			appendHtml(svg, El(dom.SVG_CIRCLE, Attr(dom.SVG_PARAM_R, 4))())
+			circle := svg.Get(dom.JS_FIRST_CHILD)
+			if circle.Get(dom.JS_NAMESPACE_URI).String() != dom.SVG_NAMESPACE {
+				t.Error("The <circle> is not in the SVG namespace")
+			}
+		},
+	},
+	{
+		"Fragment",
+		func(t *testing.T) {
+			rows := func() DomComponent {
+				return Fragment(Tr(Td(Text(1))), Tr(Td(Text(2))))
+			}
+			html := Table(Tbody(rows()))()
+			if html != "<table><tbody><tr><td>1</td></tr><tr><td>2</td></tr></tbody></table>" {
+				t.Errorf("Unexpected html rendering: %s", html)
+			}
+		},
+	},
+}
+
+// Tests of the DOM interactions of the library, which run in a browser or with the minimal DOM
+// of fakeDocument outside a browser.
+var domTests = []test{
+	{
+		"Event bindings",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			triggered := []string{}
+			Html(Div(Id("events"),
+				On("custom", func(e js.Value) { triggered = append(triggered, e.Get("type").String()) }),
+				OnKeyDown(func(e js.Value) { triggered = append(triggered, e.Get("type").String()) }),
+			))
+			setBindings()
+			div := document.Call(dom.JS_GET_ELEMENT_BY_ID, "events")
+			div.Call("dispatchEvent", js.Global().Get("Event").New("custom"))
+			div.Call("dispatchEvent", js.Global().Get("Event").New(dom.JS_EVENT_KEYDOWN))
+			if strings.Join(triggered, ",") != "custom,keydown" {
+				t.Errorf("Unexpected callbacks triggered: %v", triggered)
+			}
+		},
+	},
+	{
+		"Typed events",
+		func(t *testing.T) {
+			keyboard := NewKeyboardEvent(js.Global().Get("KeyboardEvent").New(dom.JS_EVENT_KEYDOWN, map[string]any{"key": "Enter", "ctrlKey": true}))
+			if keyboard.Type != dom.JS_EVENT_KEYDOWN || keyboard.Key != "Enter" || !keyboard.CtrlKey {
+				t.Errorf("Unexpected keyboard event: %+v", keyboard)
+			}
+			mouse := NewMouseEvent(js.Global().Get("MouseEvent").New(dom.JS_EVENT_CLICK, map[string]any{"clientX": 12, "cancelable": true}))
+			mouse.PreventDefault()
+			if mouse.ClientX != 12 || !mouse.JsValue.Get("defaultPrevented").Bool() {
+				t.Errorf("Unexpected mouse event: %+v", mouse)
+			}
+		},
+	},
+	{
+		"Event modifiers",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			keys := []string{}
+			Html(Input(Id("modifiers"), OnKeyDown(Key("Enter"), Prevent(), func(e KeyboardEvent) { keys = append(keys, e.Key) })))
+			setBindings()
+			input := document.Call(dom.JS_GET_ELEMENT_BY_ID, "modifiers")
+			for _, key := range []string{"a", "Enter"} {
+				input.Call("dispatchEvent", js.Global().Get("KeyboardEvent").New(dom.JS_EVENT_KEYDOWN, map[string]any{"key": key, "cancelable": true}))
+			}
+			if strings.Join(keys, ",") != "Enter" {
+				t.Errorf("Key filter did not apply: %v", keys)
+			}
+			event := js.Global().Get("KeyboardEvent").New(dom.JS_EVENT_KEYDOWN, map[string]any{"key": "Enter", "cancelable": true})
+			input.Call("dispatchEvent", event)
+			if !event.Get("defaultPrevented").Bool() {
+				t.Error("Default action was not prevented")
+			}
+		},
+	},
+	{
+		"Event delegation",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			triggered := []string{}
+			track := func(name string) func(js.Value) {
+				return func(_ js.Value) { triggered = append(triggered, name) }
+			}
+			Html(Div(OnClick(track("outer")), OnClick(Capture(), track("capture")),
+				Button("once", Id("once"), OnClick(Once(), track("once"))),
+				Button("stop", Id("stop"), OnClick(Stop(), track("stop"))),
+			))
+			setBindings()
+			for _, id := range []string{"once", "once", "stop"} {
+				document.Call(dom.JS_GET_ELEMENT_BY_ID, id).Call(dom.JS_EVENT_CLICK)
+			}
+			expected := "capture,once,outer,capture,outer,capture,stop"
+			if strings.Join(triggered, ",") != expected {
+				t.Errorf("Unexpected callbacks triggered: %v", triggered)
+			}
+		},
+	},
+	{
+		"Once across renderings",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			triggered := []string{}
+			track := func(name string) func(js.Value) {
+				return func(_ js.Value) { triggered = append(triggered, name) }
+			}
+			render := func() {
+				clearContext()
+				unsetBindings()
+				Html(Div(OnClick(Capture(), track("capture")),
+					Div(OnClick(track("outer")),
+						Button("once", Id("once"), OnClick(Once(), track("once"))),
+						Button("stop", Id("stop"), OnClick(Stop(), track("stop"))),
+					),
+				))
+				releaseResources()
+				setBindings()
+			}
+			for _, id := range []string{"once", "once", "stop", "once"} {
+				render()
+				document.Call(dom.JS_GET_ELEMENT_BY_ID, id).Call(dom.JS_EVENT_CLICK)
+			}
+			expected := "capture,once,outer,capture,outer,capture,stop,capture,outer"
+			if strings.Join(triggered, ",") != expected {
+				t.Errorf("Unexpected callbacks triggered: %v", triggered)
+			}
+		},
+	},
+	{
+		"Bindings of the same event",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			triggered := []string{}
+			items := []string{"a", "b"}
+			Html(For(items, func(i int) DomComponent {
+				return Button(items[i], Id(items[i]),
+					OnClick(func(_ js.Value) { triggered = append(triggered, "first:"+items[i]) }),
+					OnClick(func(_ js.Value) { triggered = append(triggered, "second:"+items[i]) }),
+				)
+			}))
+			setBindings()
+			button := document.Call(dom.JS_GET_ELEMENT_BY_ID, "b")
+			if markers := button.Get(dom.JS_ATTRIBUTES).Length(); markers != 2 {
+				t.Errorf("Unexpected number of attributes: %d", markers)
+			}
+			button.Call(dom.JS_EVENT_CLICK)
+			if strings.Join(triggered, ",") != "first:b,second:b" {
+				t.Errorf("Unexpected callbacks triggered: %v", triggered)
+			}
+		},
+	},
+	{
+		"Event delegation with native listeners",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			triggered := []string{}
+			Html(Div(OnClick(func(_ js.Value) { triggered = append(triggered, "outer") }),
+				Button("native", Id("native"), OnClick(Stop(), Prevent(), func(_ js.Value) { triggered = append(triggered, "binding") })),
+			))
+			setBindings()
+			button := document.Call(dom.JS_GET_ELEMENT_BY_ID, "native")
+			onTarget := js.FuncOf(func(_ js.Value, args []js.Value) any {
+				triggered = append(triggered, fmt.Sprintf("target:%t", args[0].Get("defaultPrevented").Bool()))
+				return nil
+			})
+			onDocument := js.FuncOf(func(_ js.Value, _ []js.Value) any {
+				triggered = append(triggered, "document")
+				return nil
+			})
+			defer onTarget.Release()
+			defer onDocument.Release()
+			button.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_CLICK, onTarget)
+			document.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_CLICK, onDocument)
+			button.Call(dom.JS_EVENT_CLICK)
+			document.Call(dom.JS_REMOVE_EVENT_LISTENER, dom.JS_EVENT_CLICK, onDocument)
+			if strings.Join(triggered, ",") != "target:false,binding,document" {
+				t.Errorf("Unexpected listeners triggered: %v", triggered)
+			}
+		},
+	},
+	{
+		"Two-way bindings",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			checked := false
+			color := "red"
+			number, _ := UseState(0.0)
+			colors := []string{"red", "blue"}
+			Html(
+				Input(Id("checkbox"), Type("checkbox"), BindChecked(&checked)),
+				For(colors, func(i int) DomComponent {
+					return Input(Id(colors[i]), Type("radio"), Value(colors[i]), BindRadio("color", &color))
+				}),
+				Input(Id("number"), Type("number"), BindNumber(number)),
+			)
+			setBindings()
+			if !document.Call(dom.JS_GET_ELEMENT_BY_ID, "red").Get(dom.JS_CHECKED).Bool() {
+				t.Error("Radio is not checked from the bound value")
+			}
+			for _, id := range []string{"checkbox", "blue"} {
+				document.Call(dom.JS_GET_ELEMENT_BY_ID, id).Call(dom.JS_EVENT_CLICK)
+			}
+			input := document.Call(dom.JS_GET_ELEMENT_BY_ID, "number")
+			input.Set(dom.JS_VALUE, "4.5")
+			input.Call("dispatchEvent", js.Global().Get("Event").New(dom.JS_EVENT_CHANGE, map[string]any{"bubbles": true}))
+			if !checked || color != "blue" || *number != 4.5 {
+				t.Errorf("Bound values are not updated: %v %v %v", checked, color, *number)
+			}
+		},
+	},
+	{
+		"Two-way bindings of the store",
+		func(t *testing.T) {
+			clearContext()
+			unsetBindings()
+			age, _ := UseState(18)
+			agreed, _ := UseState("yes")
+			Html(Input(Id("age"), Type("number"), BindNumber(age)), Input(Id("agreed"), Type("checkbox"), Attr("checked", true), BindChecked(agreed)))
+			setBindings()
+			input := document.Call(dom.JS_GET_ELEMENT_BY_ID, "age")
+			if input.Get(dom.JS_VALUE_AS_NUMBER).Float() != 18 {
+				t.Errorf("Unexpected value of the input: %v", input.Get(dom.JS_VALUE))
+			}
+			if !document.Call(dom.JS_GET_ELEMENT_BY_ID, "agreed").Get(dom.JS_CHECKED).Bool() {
+				t.Error("Value of another type written")
+			}
+			input.Set(dom.JS_VALUE, "21")
+			input.Call("dispatchEvent", js.Global().Get("Event").New(dom.JS_EVENT_CHANGE, map[string]any{"bubbles": true}))
+			if *age != 21 {
+				t.Errorf("Unexpected bound value: %#v", *age)
+			}
+		},
+	},
+	{
+		"Files",
+		func(t *testing.T) {
+			options := map[string]any{"type": "text/plain"}
+			file := NewFile(js.Global().Get("File").New([]any{"gooroo"}, "notes.txt", options))
+			if file.Name != "notes.txt" || file.Size != 6 || file.Type != "text/plain" {
+				t.Errorf("Unexpected file %+v", file)
+			}
+			read := make(chan string)
+			file.Read(func(content []byte, err error) {
+				read <- string(content)
+			})
+			if content := <-read; content != "gooroo" {
+				t.Errorf("Unexpected content %q", content)
+			}
+			if (UploadProgress{Loaded: 25, Total: 50}).Percent() != 50 {
+				t.Error("Unexpected upload percentage")
+			}
+		},
+	},
+	{
+		"Signal bindings",
+		func(t *testing.T) {
+			clearContext()
+			unsetSignals()
+			label := NewSignal("draft")
+			locked := NewSignal(false)
+			Html(
+				Div(Id("label"), SignalText[string](label)),
+				Button("Save", Id("save"), SignalAttr[bool]("disabled", locked), SignalAttr[bool]("aria-busy", locked), SignalAttr[string]("title", label)),
+			)
+			setSignals()
+			label.Set("published <b>")
+			locked.Set(true)
+			updates.Drain()
+			save := document.Call(dom.JS_GET_ELEMENT_BY_ID, "save")
+			text := document.Call(dom.JS_GET_ELEMENT_BY_ID, "label").Get(dom.JS_TEXT_CONTENT).String()
+			if text != "published <b>" || !save.Get(dom.JS_DISABLED).Bool() || save.Call("getAttribute", "title").String() != "published <b>" {
+				t.Error("Elements not patched from the signals")
+			}
+			if !save.Call("hasAttribute", "aria-busy").Bool() || !save.Get("aria-busy").IsUndefined() {
+				t.Error("Aria attribute not patched as an attribute only")
+			}
+			locked.Set(false)
+			updates.Drain()
+			if save.Call("hasAttribute", "aria-busy").Bool() {
+				t.Error("Aria attribute not removed")
+			}
+		},
+	},
+	{
+		"Preserve focus & selection",
+		func(t *testing.T) {
+			render := func() {
+				Html(Div(P("text"), Input(Id("focus"), Value("hello"))))
+			}
+			clearContext()
+			render()
+			input := document.Call(dom.JS_GET_ELEMENT_BY_ID, "focus")
+			input.Call(dom.JS_EVENT_FOCUS)
+			input.Call(dom.JS_SET_SELECTION_RANGE, 1, 3)
+			view := saveView()
+			clearContext()
+			render()
+			restoreView(view)
+			active := document.Get(dom.JS_ACTIVE_ELEMENT)
+			if active.Get("id").String() != "focus" {
+				t.Error("Focus is not restored")
+			}
+			if active.Get(dom.JS_SELECTION_START).Int() != 1 || active.Get(dom.JS_SELECTION_END).Int() != 3 {
+				t.Error("Selection is not restored")
+			}
+		},
+	},
+	{
+		"Preserve focus across inserted elements",
+		func(t *testing.T) {
+			render := func(errors bool) js.Value {
+				clearContext()
+				unsetBindings()
+				message := func(text string) DomComponent {
+					if errors {
+						return P(text)
+					}
+					return Fragment()
+				}
+				Html(Div(
+					message("first"), Input(Attr("name", "first")),
+					message("second"), Input(Attr("name", "second")),
+					message("notes"), TextArea(OnInput(func(_ js.Value) {})),
+				))
+				return document.Get(dom.HTML_BODY).Get(dom.JS_FIRST_CHILD).Get(dom.JS_CHILDREN)
+			}
+			for _, index := range []int{1, 2} {
+				render(false).Index(index).Call(dom.JS_EVENT_FOCUS)
+				view := saveView()
+				expected := render(true).Index(2*index + 1)
+				restoreView(view)
+				if !document.Get(dom.JS_ACTIVE_ELEMENT).Equal(expected) {
+					t.Errorf("Focus is not restored on the element %d", index)
+				}
+			}
+		},
+	},
+}
+
+// Tests of the state of the application, which do not need the DOM and run in any JavaScript
+// environment (node with go_js_wasm_exec for example).
+var stateTests = []test{
+	{
+		"updateState",
+		func(t *testing.T) {
+			updateState()
+			updateState()
+			if len(state) != 1 {
+				t.Error("The rendering requests are not coalesced")
+			}
+			<-state
+		},
+	},
+	{
+		"runOnLoop",
+		func(t *testing.T) {
+			atomic.StoreInt32(&looping, 1)
+			defer atomic.StoreInt32(&looping, 0)
+			ran := make(chan bool, 1)
+			go runOnLoop(func() { ran <- true })
+			time.Sleep(10 * time.Millisecond)
+			if len(ran) != 0 {
+				t.Error("Function run outside of the render loop")
+			}
+			updates.Drain()
+			<-ran
+			atomic.StoreInt32(&rendering, 1)
+			runOnLoop(func() { ran <- true })
+			atomic.StoreInt32(&rendering, 0)
+			if len(ran) != 0 {
+				t.Error("Function run during the rendering")
+			}
+			updates.Drain()
+			<-ran
+			<-state
+		},
+	},
+	{
+		"runOnLoop from the render loop",
+		func(t *testing.T) {
+			atomic.StoreInt32(&looping, 1)
+			defer atomic.StoreInt32(&looping, 0)
+			ran := false
+			// a handler of an event emitted synchronously by an update (a blur caused by focus() ...)
+			handler := js.FuncOf(func(_ js.Value, _ []js.Value) any {
+				runOnLoop(func() { ran = true })
+				return nil
+			})
+			defer handler.Release()
+			enqueue(func() { handler.Invoke() })
+			applyUpdates()
+			if !ran {
+				t.Error("Nested function not run")
+			}
+			<-state
+		},
+	},
+	{
+		"Debounce & Throttle",
+		func(t *testing.T) {
+			debounced, throttled := 0, 0
+			for i := 0; i < 3; i++ {
+				schedule("debounce", Debounce(10*time.Millisecond), func() { debounced++ })
+				schedule("throttle", Throttle(10*time.Millisecond), func() { throttled++ })
+			}
+			if debounced != 0 || throttled != 1 {
+				t.Errorf("Unexpected immediate runs: %d debounced, %d throttled", debounced, throttled)
+			}
+			time.Sleep(50 * time.Millisecond)
+			// the delayed runs are applied by the render loop
+			updates.Drain()
+			if debounced != 1 || throttled != 2 {
+				t.Errorf("Unexpected delayed runs: %d debounced, %d throttled", debounced, throttled)
+			}
+		},
+	},
+	{
+		"Debounced value binding",
+		func(t *testing.T) {
+			commits, calls := 0, 0
+			value := &domValue{func(js.Value) {}, func(js.Value) {}, func() { commits++ }}
+			modifier := Debounce(10 * time.Millisecond)
+			change := generateBinding("[debounced]", dom.JS_EVENT_CHANGE, value, modifier, func(js.Value) { calls++ })
+			input := generateBinding("[debounced]", dom.JS_EVENT_INPUT, value, modifier.timing())
+			e := js.ValueOf(map[string]any{dom.JS_TARGET: map[string]any{}})
+			input.callback(e)
+			change.callback(e)
+			time.Sleep(50 * time.Millisecond)
+			updates.Drain()
+			if commits != 1 || calls != 1 {
+				t.Errorf("Edit committed %d times, callbacks called %d times", commits, calls)
+			}
+		},
+	},
+	{
+		"Concurrent setters",
+		func(t *testing.T) {
+			count, setCount := UseState(-1)
+			wg := sync.WaitGroup{}
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					setCount(i)
+				}(i)
+			}
+			wg.Wait()
+			if *count != -1 {
+				t.Error("State updated outside of the render loop")
+			}
+			if applied := updates.Drain(); applied != 50 {
+				t.Errorf("Expected 50 updates, got %d", applied)
+			}
+			if *count == -1 || !detectHasChanged(count) {
+				t.Error("State not updated by the render loop")
+			}
+			clearHasChange()
+		},
+	},
+	{
+		"Queries",
+		func(t *testing.T) {
+			fetches := 0
+			fetched := make(chan bool)
+			fetcher := func(ctx context.Context) (string, error) {
+				fetches++
+				fetched <- true
+				return fmt.Sprintf("data %d", fetches), nil
+			}
+			render := func() (Query[string], Query[string]) {
+				first, second := UseQuery("users", fetcher), UseQuery("users", fetcher)
+				releaseResources()
+				return first, second
+			}
+			first, second := render()
+			if !first.Loading || !second.Loading {
+				t.Error("Queries not loading")
+			}
+			<-fetched
+			updates.Drain()
+			first, _ = render()
+			if first.Loading || first.Data != "data 1" || fetches != 1 {
+				t.Errorf("Unexpected query %+v after %d fetches", first, fetches)
+			}
+			first.Refetch()
+			updates.Drain()
+			if first, _ = render(); !first.Fetching || first.Data != "data 1" {
+				t.Error("Cached data not returned while revalidating")
+			}
+			<-fetched
+			canceled := UseQuery("canceled", func(ctx context.Context) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			})
+			releaseResources()
+			releaseResources()
+			updates.Drain()
+			if !canceled.Loading || queries["canceled"].fetching {
+				t.Error("Query not canceled when unmounted")
+			}
+		},
+	},
+	{
+		"Mutations",
+		func(t *testing.T) {
+			fetched := make(chan bool, 2)
+			written := make(chan bool)
+			render := func() (Query[[]string], Mutation[string, int]) {
+				query := UseQuery("todos", func(ctx context.Context) ([]string, error) {
+					fetched <- true
+					return []string{"write tests"}, nil
+				})
+				add := UseMutation(func(ctx context.Context, todo string) (int, error) {
+					<-written
+					return 0, fmt.Errorf("%q rejected", todo)
+				}, MutationOptions[string, int]{
+					OnMutate: func(todo string) func() {
+						previous, _ := GetQueryData[[]string]("todos")
+						return SetQueryData("todos", append(previous, todo))
+					},
+					Invalidate: []string{"todos"},
+				})
+				releaseResources()
+				return query, add
+			}
+			_, add := render()
+			<-fetched
+			updates.Drain()
+			add.Mutate("ship it")
+			updates.Drain()
+			query, add := render()
+			if !add.Pending {
+				t.Error("Mutation not pending")
+			}
+			if len(query.Data) != 2 || query.Fetching {
+				t.Errorf("Query not updated optimistically: %+v", query)
+			}
+			written <- true
+			time.Sleep(10 * time.Millisecond)
+			updates.Drain()
+			query, _ = render()
+			if len(query.Data) != 1 || !query.Fetching {
+				t.Errorf("Query not rolled back and invalidated: %+v", query)
+			}
+			<-fetched
+			if _, add := render(); add.Pending || add.Error == nil {
+				t.Errorf("Unexpected mutation state %+v", add)
+			}
+		},
+	},
+	{
+		"Mutation cancellation",
+		func(t *testing.T) {
+			canceled := make(chan string, 2)
+			done := make(chan bool)
+			effects := []string{}
+			options := func(name string) MutationOptions[string, int] {
+				return MutationOptions[string, int]{
+					OnMutate: func(todo string) func() {
+						return func() { effects = append(effects, "rollback:"+name+":"+todo) }
+					},
+					OnError: func(err error, todo string) { effects = append(effects, "error:"+name+":"+todo) },
+				}
+			}
+			useAdd := func(mounted bool) Mutation[string, int] {
+				var add Mutation[string, int]
+				if mounted {
+					add = UseMutation(func(ctx context.Context, todo string) (int, error) {
+						select {
+						case <-ctx.Done():
+							canceled <- todo
+							return 0, ctx.Err()
+						case <-done:
+							return 0, fmt.Errorf("%s failed", todo)
+						}
+					}, options("first"), options("second"))
+				}
+				releaseResources()
+				return add
+			}
+			useAdd(true).Mutate("superseded")
+			updates.Drain()
+			useAdd(true).Mutate("unmounted")
+			updates.Drain()
+			if todo := <-canceled; todo != "superseded" {
+				t.Errorf("Superseded write not canceled: %s", todo)
+			}
+			useAdd(false)
+			time.Sleep(10 * time.Millisecond)
+			if len(canceled) != 0 {
+				t.Error("Write canceled on unmount")
+			}
+			close(done)
+			time.Sleep(10 * time.Millisecond)
+			updates.Drain()
+			expected := "rollback:second:unmounted,rollback:first:unmounted,error:first:unmounted,error:second:unmounted"
+			if strings.Join(effects, ",") != expected {
+				t.Errorf("Unexpected effects: %v", effects)
+			}
+		},
+	},
+	{
+		"WebSocket",
+		func(t *testing.T) {
+			js.Global().Set("WebSocket", js.Global().Get("Function").New(`
+				this.listeners = {};
+				this.sent = [];
+				this.addEventListener = (event, listener) => { this.listeners[event] = listener; };
+				this.removeEventListener = (event) => { delete this.listeners[event]; };
+				this.send = (message) => this.sent.push(message);
+				this.close = () => { this.closed = true; };
+				globalThis.lastSocket = this;
+			`))
+			type tick struct {
+				Value int `json:"value"`
+			}
+			url := "wss://example.com/ticks"
+			render := func() WebSocket[tick] {
+				ws := UseWebSocket[tick](url, ReconnectBackoff(time.Millisecond, time.Millisecond))
+				releaseResources()
+				return ws
+			}
+			if ws := render(); ws.Status != SOCKET_CONNECTING || ws.Send("ping") == nil {
+				t.Error("Socket not connecting")
+			}
+			socket := js.Global().Get("lastSocket")
+			socket.Get("listeners").Call("open", map[string]any{})
+			updates.Drain()
+			ws := render()
+			if ws.Status != SOCKET_OPEN || ws.Send(tick{1}) != nil || socket.Get("sent").Index(0).String() != `{"value":1}` {
+				t.Error("Message not sent through the open socket")
+			}
+			socket.Get("listeners").Call("message", map[string]any{"data": `{"value":42}`})
+			updates.Drain()
+			if ws := render(); ws.Message.Value != 42 {
+				t.Errorf("Message not decoded: %+v", ws)
+			}
+			frame := js.Global().Get("Uint8Array").New(len(`{"value":7}`))
+			js.CopyBytesToJS(frame, []byte(`{"value":7}`))
+			socket.Get("listeners").Call("message", map[string]any{"data": frame.Get("buffer")})
+			updates.Drain()
+			if ws := render(); ws.Message.Value != 7 || ws.Send([]byte{1}) != nil || socket.Get("sent").Index(1).Index(0).Int() != 1 {
+				t.Errorf("Binary frames not handled: %+v", ws)
+			}
+			socket.Get("listeners").Call("close", map[string]any{})
+			updates.Drain()
+			time.Sleep(10 * time.Millisecond)
+			updates.Drain()
+			if ws := render(); ws.Status != SOCKET_CONNECTING || js.Global().Get("lastSocket").Equal(socket) {
+				t.Error("Socket not reconnected")
+			}
+			// the URL changes once the reconnection is queued
+			js.Global().Get("lastSocket").Get("listeners").Call("close", map[string]any{})
+			updates.Drain()
+			time.Sleep(10 * time.Millisecond)
+			url = "wss://example.com/other"
+			render()
+			socket = js.Global().Get("lastSocket")
+			updates.Drain()
+			if !js.Global().Get("lastSocket").Equal(socket) || socket.Get("listeners").Get("open").IsUndefined() {
+				t.Error("Socket replaced by a stale reconnection")
+			}
+			releaseResources()
+			if !js.Global().Get("lastSocket").Get("closed").Truthy() {
+				t.Error("Socket not closed when unmounted")
+			}
+		},
+	},
+	{
+		"EventSource",
+		func(t *testing.T) {
+			js.Global().Set("EventSource", js.Global().Get("Function").New(`
+				this.listeners = {};
+				this.readyState = 0;
+				this.addEventListener = (event, listener) => { this.listeners[event] = listener; };
+				this.removeEventListener = (event) => { delete this.listeners[event]; };
+				this.close = () => { this.readyState = 2; };
+				globalThis.lastSource = this;
+			`))
+			type build struct {
+				Step string `json:"step"`
+			}
+			steps := []string{}
+			logs := []string{}
+			render := func(options ...EventSourceOption) EventSource[string] {
+				options = append(options, HandleEvent("build", func(b build) {
+					steps = append(steps, b.Step)
+				}), ReconnectBackoff(time.Millisecond, time.Millisecond))
+				es := UseEventSource[string]("/builds/1", options...)
+				releaseResources()
+				return es
+			}
+			render()
+			source := js.Global().Get("lastSource")
+			source.Get("listeners").Call("open", map[string]any{})
+			source.Get("listeners").Call("build", map[string]any{"data": `{"step":"test"}`, "lastEventId": "7"})
+			updates.Drain()
+			if es := render(); es.Status != SOCKET_OPEN || es.Event != "build" || es.Message != "" || es.LastEventId != "7" {
+				t.Errorf("Unexpected event source %+v", es)
+			}
+			source.Get("listeners").Call("message", map[string]any{"data": "started", "lastEventId": "8"})
+			updates.Drain()
+			if es := render(); es.Event != "message" || es.Message != "started" || es.Error != nil {
+				t.Errorf("Unexpected message %+v", es)
+			}
+			if len(steps) != 1 || steps[0] != "test" {
+				t.Errorf("Event not handled: %v", steps)
+			}
+			render(HandleEvent("log", func(line string) {
+				logs = append(logs, line)
+			}))
+			if !source.Get("listeners").Get("log").Truthy() {
+				t.Fatal("Handler added after the connection not listened")
+			}
+			source.Get("listeners").Call("log", map[string]any{"data": "compiling", "lastEventId": "9"})
+			updates.Drain()
+			if len(logs) != 1 || logs[0] != "compiling" {
+				t.Errorf("Event not handled: %v", logs)
+			}
+			if es := render(); es.Message != "started" {
+				t.Errorf("Message replaced by a named event: %+v", es)
+			}
+			source.Set("readyState", 2)
+			source.Get("listeners").Call("error", map[string]any{})
+			updates.Drain()
+			time.Sleep(10 * time.Millisecond)
+			updates.Drain()
+			if es := render(); es.Status != SOCKET_CONNECTING || js.Global().Get("lastSource").Equal(source) {
+				t.Error("Event source not reconnected")
+			}
+			releaseResources()
+			if js.Global().Get("lastSource").Get("readyState").Int() != 2 {
+				t.Error("Event source not closed when unmounted")
+			}
+		},
+	},
+	{
+		"Persistent state",
+		func(t *testing.T) {
+			js.Global().Set("sessionStorage", js.Global().Get("Function").New(`
+				const items = { "filters": '{"query":"go","page":2}' };
+				return {
+					getItem: (key) => key in items ? items[key] : null,
+					setItem: (key, value) => { items[key] = value; },
+				};
+			`).Invoke())
+			type filters struct {
+				Query string `json:"query"`
+				Page  int    `json:"page"`
+			}
+			value, setValue := UsePersistentState("filters", filters{}, SESSION_STORAGE)
+			if hydrated := (*value).(filters); hydrated.Query != "go" || hydrated.Page != 2 {
+				t.Errorf("Value not hydrated from the storage: %+v", hydrated)
+			}
+			setValue(filters{"wasm", 1})
+			updates.Drain()
+			stored := js.Global().Get("sessionStorage").Call("getItem", "filters").String()
+			if stored != `{"query":"wasm","page":1}` || (*value).(filters).Query != "wasm" {
+				t.Errorf("Value not persisted: %s", stored)
+			}
+			storageListener.Invoke(map[string]any{
+				"key":         "filters",
+				"newValue":    `{"query":"other tab","page":3}`,
+				"storageArea": js.Global().Get("sessionStorage"),
+			})
+			updates.Drain()
+			if synced := (*value).(filters); synced.Query != "other tab" || !detectHasChanged(value) {
+				t.Errorf("Value not synchronized with the other tabs: %+v", synced)
+			}
+			clearHasChange()
+			// a disabled storage throws when it is read, a full one when it is written
+			js.Global().Get("Object").Call("defineProperty", js.Global(), "localStorage", map[string]any{
+				"get":          js.Global().Get("Function").New(`throw new Error("SecurityError")`),
+				"configurable": true,
+			})
+			defer js.Global().Get("Reflect").Call("deleteProperty", js.Global(), "localStorage")
+			theme, setTheme := UsePersistentState("theme", "light", LOCAL_STORAGE)
+			setTheme("dark")
+			updates.Drain()
+			if *theme != "dark" {
+				t.Errorf("Value not kept without storage: %v", *theme)
+			}
+			js.Global().Get("sessionStorage").Set("setItem", js.Global().Get("Function").New(`throw new Error("QuotaExceededError")`))
+			setValue(filters{"full", 1})
+			updates.Drain()
+			if (*value).(filters).Query != "full" {
+				t.Error("Value not kept when the storage is full")
+			}
+			clearHasChange()
+		},
+	},
+	{
+		"Global store",
+		func(t *testing.T) {
+			type app struct {
+				Count int
+				Theme string
+			}
+			logged := []any{}
+			counter := NewStore(app{0, "light"}, func(state app, action any) app {
+				switch action {
+				case "increment":
+					state.Count++
+				case "dark":
+					state.Theme = "dark"
+				}
+				return state
+			}, func(store *Store[app], action any, next func(action any)) {
+				logged = append(logged, action)
+				next(action)
+			})
+			useCount := func() int {
+				count := UseSelector(counter, func(state app) int { return state.Count })
+				releaseResources()
+				return count
+			}
+			useCount()
+			atomic.StoreInt32(&renderRequested, 0)
+			counter.Dispatch("dark")
+			updates.Drain()
+			if counter.State().Theme != "dark" || atomic.LoadInt32(&renderRequested) != 0 {
+				t.Error("Rendering requested for a slice that is not selected")
+			}
+			counter.Dispatch("increment")
+			updates.Drain()
+			if atomic.LoadInt32(&renderRequested) != 1 || useCount() != 1 {
+				t.Error("Rendering not requested for a changed slice")
+			}
+			if len(logged) != 2 || logged[1] != "increment" {
+				t.Errorf("Actions not passed through the middleware: %v", logged)
+			}
+			clearHasChange()
+		},
+	},
+	{
+		"Signals",
+		func(t *testing.T) {
+			count := NewSignal(1)
+			double := Computed(func() int { return count.Get() * 2 })
+			runs := 0
+			seen := 0
+			dispose := Effect(func() {
+				runs++
+				seen = double.Get()
+			})
+			count.Set(2)
+			count.Update(func(value int) int { return value + 1 })
+			count.Set(3)
+			updates.Drain()
+			if seen != 6 || runs != 3 {
+				t.Errorf("Effect not run on changes: saw %d after %d runs", seen, runs)
+			}
+			dispose()
+			count.Set(5)
+			updates.Drain()
+			if seen != 6 || double.Peek() != 10 {
+				t.Error("Disposed effect still run")
+			}
+			atomic.StoreInt32(&rendering, 1)
+			rendered := Computed(func() int { return count.Get() * 3 })
+			Effect(func() { seen = rendered.Get() })
+			atomic.StoreInt32(&rendering, 0)
+			unsetSignals()
+			count.Set(7)
+			updates.Drain()
+			if seen != 15 || rendered.Peek() != 15 {
+				t.Error("Computations of a rendering not stopped before the next one")
+			}
+			inner := 0
+			filter := NewSignal("")
+			Effect(func() {
+				count.Get()
+				Effect(func() {
+					filter.Get()
+					inner++
+				})
+			})
+			count.Set(8)
+			count.Set(9)
+			updates.Drain()
+			filter.Set("done")
+			updates.Drain()
+			if inner != 4 {
+				t.Errorf("Computations of the previous runs not stopped: %d runs", inner)
+			}
+		},
+	},
+	{
+		"History state",
+		func(t *testing.T) {
+			type point struct{ X, Y int }
+			render := func() History[point] {
+				return UseHistoryState(point{0, 0}, 2)
+			}
+			history := render()
+			history.Set(point{1, 1})
+			history.Begin()
+			for x := 2; x <= 5; x++ {
+				history.Set(point{x, x})
+			}
+			history.Commit()
+			updates.Drain()
+			if history = render(); history.Value != (point{5, 5}) || !history.CanUndo {
+				t.Errorf("Unexpected value %+v", history.Value)
+			}
+			history.Undo()
+			updates.Drain()
+			if history = render(); history.Value != (point{1, 1}) || !history.CanRedo {
+				t.Errorf("Drag not undone as a single step: %+v", history.Value)
+			}
+			history.Redo()
+			history.Set(point{6, 6})
+			history.Undo()
+			history.Undo()
+			history.Undo()
+			updates.Drain()
+			if history = render(); history.Value != (point{1, 1}) || history.CanUndo {
+				t.Errorf("History not bounded: %+v", history.Value)
+			}
+			renderPath := func() History[[]point] {
+				return UseHistoryState([]point{{0, 0}}, 0)
+			}
+			path := renderPath()
+			path.Value[0].X = 1
+			path.Set(path.Value)
+			updates.Drain()
+			points := renderPath().Value
+			points = append(points, point{2, 2})
+			path.Set(points)
+			points[0].X = 3
+			updates.Drain()
+			if path = renderPath(); len(path.Value) != 2 || path.Value[0].X != 1 {
+				t.Errorf("Step modified in place: %+v", path.Value)
+			}
+			path.Undo()
+			updates.Drain()
+			if path = renderPath(); len(path.Value) != 1 || path.Value[0].X != 1 {
+				t.Errorf("Slice mutation not undone: %+v", path.Value)
+			}
+			path.Undo()
+			updates.Drain()
+			if path = renderPath(); path.Value[0].X != 0 || path.CanUndo {
+				t.Errorf("Initial value modified in place: %+v", path.Value)
+			}
+			clearHasChange()
+		},
+	},
 }
 
 func Test_All(t *testing.T) {
@@ -96,3 +1116,252 @@ func Test_All(t *testing.T) {
 	}
 
 }
+
+func Test_State(t *testing.T) {
+
+	if js.Global().Get(dom.JS_ADD_EVENT_LISTENER).IsUndefined() {
+		// outside a browser, the global object does not emit any event
+		js.Global().Set(dom.JS_ADD_EVENT_LISTENER, js.FuncOf(func(_ js.Value, _ []js.Value) any { return nil }))
+	}
+
+	for _, test := range stateTests {
+		fmt.Println(fmt.Sprintf("Test: %s", test.name))
+		t.Run(test.name, test.function)
+	}
+
+}
+
+func Test_Dom(t *testing.T) {
+
+	if document.IsUndefined() {
+		voids := []any{}
+		for _, tag := range dom.HTML_VOID_ELEMENTS {
+			voids = append(voids, tag)
+		}
+		document = js.Global().Get("Function").New("voids", fakeDocument).Invoke(voids)
+		defer func() {
+			document = js.Undefined()
+			listeners = make(map[string]js.Func)
+		}()
+	}
+
+	for _, test := range domTests {
+		fmt.Println(fmt.Sprintf("Test: %s", test.name))
+		t.Run(test.name, test.function)
+	}
+
+}
+
+// Minimal DOM used outside a browser: the html rendered by the library is parsed into elements,
+// the selectors are limited to the attribute selectors used by the library, and the events are
+// dispatched through the capture, target and bubbling phases. The missing globals used by the
+// tests (KeyboardEvent, MouseEvent, FileReader, scrollTo) are defined if needed.
+const fakeDocument = `
+const VOID = new Set(voids);
+const ENTITIES = { amp: '&', lt: '<', gt: '>', quot: '"', apos: "'", '#39': "'" };
+const decode = (text) => text.replace(/&(#?\w+);/g, (entity, name) => ENTITIES[name] ?? entity);
+const modifiers = { altKey: false, ctrlKey: false, shiftKey: false, metaKey: false };
+const defineEvent = (name, defaults) => {
+	globalThis[name] ??= class extends Event {
+		constructor(type, init = {}) {
+			super(type, init);
+			for (const [key, value] of Object.entries(defaults)) this[key] = init[key] ?? value;
+		}
+	};
+};
+defineEvent('KeyboardEvent', { key: '', code: '', repeat: false, ...modifiers });
+defineEvent('MouseEvent', { clientX: 0, clientY: 0, pageX: 0, pageY: 0, offsetX: 0, offsetY: 0, button: 0, buttons: 0, ...modifiers });
+if (typeof globalThis.scrollTo !== 'function') {
+	Object.assign(globalThis, { scrollX: 0, scrollY: 0, scrollTo() {} });
+}
+globalThis.FileReader ??= class extends EventTarget {
+	readAsArrayBuffer(blob) {
+		blob.arrayBuffer().then((result) => {
+			this.result = result;
+			this.dispatchEvent(new Event('load'));
+		}, (error) => {
+			this.error = error;
+			this.dispatchEvent(new Event('error'));
+		});
+	}
+};
+
+const ATTRIBUTE_SELECTOR = /\[([^\]~=]+)(?:(~?=)'([^']*)')?\]/g;
+const matches = (element, selector) => {
+	const conditions = [...selector.matchAll(ATTRIBUTE_SELECTOR)];
+	if (conditions.map(([condition]) => condition).join('') !== selector) {
+		throw new Error('Unsupported selector ' + selector);
+	}
+	return conditions.every(([, name, operator, value]) => {
+		const actual = element.getAttribute(name);
+		if (actual === null || !operator) {
+			return actual !== null;
+		}
+		return operator === '=' ? actual === value : actual.split(/\s+/).includes(value);
+	});
+};
+const define = (event, name, value) => Object.defineProperty(event, name, { value, configurable: true });
+
+class Node {
+	constructor(nodeType) {
+		this.nodeType = nodeType;
+		this.childNodes = [];
+		this.parentNode = null;
+		this.listeners = [];
+	}
+	get firstChild() { return this.childNodes[0] ?? null; }
+	get children() { return this.childNodes.filter((node) => node.nodeType === 1); }
+	get parentElement() { return this.parentNode?.nodeType === 1 ? this.parentNode : null; }
+	get isConnected() { return this === doc || !!this.parentNode?.isConnected; }
+	get textContent() { return this.childNodes.map((node) => node.textContent).join(''); }
+	set textContent(text) { this.replaceChildren(new Text(String(text))); }
+	set innerHTML(html) {
+		this.replaceChildren();
+		let parent = this;
+		for (const [, closing, name, attributes, text] of html.matchAll(/<(\/?)([a-zA-Z][\w:-]*)((?:\s+[^\s=>]+(?:='[^']*')?)*)\s*>|([^<]+)/g)) {
+			if (text !== undefined) {
+				parent.appendChild(new Text(decode(text)));
+			} else if (closing) {
+				parent = parent === this ? this : parent.parentNode;
+			} else {
+				const element = new Element(name);
+				for (const [, name, value] of attributes.matchAll(/([^\s=]+)(?:='([^']*)')?/g)) {
+					// as in a browser, only the first of duplicated attributes is kept
+					element.hasAttribute(name) || element.setAttribute(name, decode(value ?? ''));
+				}
+				element.reset();
+				parent.appendChild(element);
+				parent = VOID.has(element.localName) ? parent : element;
+			}
+		}
+	}
+	appendChild(node) {
+		node.parentNode?.childNodes.splice(node.parentNode.childNodes.indexOf(node), 1);
+		node.parentNode = this;
+		this.childNodes.push(node);
+		return node;
+	}
+	replaceChildren(...nodes) {
+		this.childNodes.forEach((node) => node.parentNode = null);
+		this.childNodes = [];
+		nodes.forEach((node) => this.appendChild(node));
+	}
+	querySelectorAll(selector) {
+		return this.children.flatMap((element) => [...(matches(element, selector) ? [element] : []), ...element.querySelectorAll(selector)]);
+	}
+	querySelector(selector) { return this.querySelectorAll(selector)[0] ?? null; }
+	addEventListener(type, listener, options) {
+		const capture = options === true || !!options?.capture;
+		this.removeEventListener(type, listener, options);
+		this.listeners.push({ type, listener, capture });
+	}
+	removeEventListener(type, listener, options) {
+		const capture = options === true || !!options?.capture;
+		this.listeners = this.listeners.filter((l) => l.type !== type || l.listener !== listener || l.capture !== capture);
+	}
+	dispatchEvent(event) {
+		const path = [];
+		for (let node = this; node; node = node.parentNode) {
+			path.push(node);
+		}
+		define(event, 'target', this);
+		const invoke = (node, phase) => {
+			define(event, 'eventPhase', phase);
+			define(event, 'currentTarget', node);
+			for (const l of node.listeners.slice()) {
+				if (l.type === event.type && (phase === 2 || l.capture === (phase === 1))) {
+					typeof l.listener === 'function' ? l.listener.call(node, event) : l.listener.handleEvent(event);
+				}
+			}
+		};
+		for (let i = path.length - 1; i > 0 && !event.cancelBubble; i--) {
+			invoke(path[i], 1);
+		}
+		if (!event.cancelBubble) {
+			invoke(this, 2);
+		}
+		for (let i = 1; i < path.length && event.bubbles && !event.cancelBubble; i++) {
+			invoke(path[i], 3);
+		}
+		define(event, 'eventPhase', 0);
+		define(event, 'currentTarget', null);
+		return !event.defaultPrevented;
+	}
+}
+
+class Text extends Node {
+	constructor(data) {
+		super(3);
+		this.data = data;
+	}
+	get textContent() { return this.data; }
+	set textContent(data) { this.data = String(data); }
+}
+
+class Element extends Node {
+	constructor(name) {
+		super(1);
+		this.localName = name.toLowerCase();
+		this.tagName = name.toUpperCase();
+		this.namespaceURI = 'http://www.w3.org/1999/xhtml';
+		this.attributes = [];
+		this.reset();
+	}
+	// sets the properties from the attributes
+	reset() {
+		this.value = this.getAttribute('value') ?? '';
+		this.checked = this.hasAttribute('checked');
+		const text = this.localName === 'textarea' || (this.localName === 'input' && ['text', 'search', 'password'].includes(this.getAttribute('type') ?? 'text'));
+		this.selectionStart = this.selectionEnd = text ? 0 : null;
+		this.selectionDirection = text ? 'none' : null;
+	}
+	get id() { return this.getAttribute('id') ?? ''; }
+	get name() { return this.getAttribute('name') ?? ''; }
+	get valueAsNumber() { return this.value === '' ? NaN : Number(this.value); }
+	set valueAsNumber(number) { this.value = isNaN(number) ? '' : String(number); }
+	getAttribute(name) { return this.attributes.find((a) => a.name === name)?.value ?? null; }
+	hasAttribute(name) { return this.getAttribute(name) !== null; }
+	setAttribute(name, value) {
+		this.removeAttribute(name);
+		this.attributes.push({ name, value: String(value) });
+	}
+	removeAttribute(name) { this.attributes = this.attributes.filter((a) => a.name !== name); }
+	focus() { doc.focused = this; }
+	setSelectionRange(start, end, direction = 'none') {
+		Object.assign(this, { selectionStart: start, selectionEnd: end, selectionDirection: direction });
+	}
+	click() {
+		const checkable = this.localName === 'input' && ['checkbox', 'radio'].includes(this.getAttribute('type'));
+		const checked = this.checked;
+		if (checkable) {
+			this.checked = this.getAttribute('type') === 'radio' || !checked;
+		}
+		if (!this.dispatchEvent(new MouseEvent('click', { bubbles: true, cancelable: true }))) {
+			this.checked = checked;
+		} else if (checkable && this.checked !== checked) {
+			if (this.getAttribute('type') === 'radio') {
+				doc.querySelectorAll("[name='" + this.name + "']").filter((radio) => radio !== this).forEach((radio) => radio.checked = false);
+			}
+			this.dispatchEvent(new Event('input', { bubbles: true }));
+			this.dispatchEvent(new Event('change', { bubbles: true }));
+		}
+	}
+}
+
+class Document extends Node {
+	constructor() {
+		super(9);
+	}
+	// the focus is lost when the focused element is removed
+	get activeElement() { return this.focused?.isConnected ? this.focused : this.body; }
+	createElement(name) { return new Element(name); }
+	createElementNS(_, name) { return new Element(name); }
+	getElementById(id) { return this.querySelector("[id='" + id + "']"); }
+}
+
+const doc = new Document();
+doc.documentElement = doc.appendChild(new Element('html'));
+doc.head = doc.documentElement.appendChild(new Element('head'));
+doc.body = doc.documentElement.appendChild(new Element('body'));
+return doc;
+`
@@ -0,0 +1,129 @@
+package gooroo
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// History state
+
+const HISTORY_STORE_PREFIX = "history:"
+
+// History describes a value of the store with its undo / redo history.
+type History[T any] struct {
+	// Current value, a copy that can be modified without changing the history.
+	Value T
+	// Records a new value as a step of the history, and forgets the undone steps.
+	Set func(value T)
+	// Restores the value before the last step.
+	Undo func()
+	// Restores the value of the last undone step.
+	Redo func()
+	// True if a step can be undone.
+	CanUndo bool
+	// True if an undone step can be redone.
+	CanRedo bool
+	// Starts a transaction: all the values set until Commit() count as a single step (as
+	// the moves of a drag operation).
+	Begin func()
+	// Ends the transaction started by Begin().
+	Commit func()
+	// Forgets all the steps of the history, keeping the current value.
+	Clear func()
+}
+
+// HistoryState retains the steps of a history across the renderings.
+type historyState[T any] struct {
+	past     []T
+	present  T
+	future   []T
+	limit    int
+	grouping bool
+	recorded bool
+}
+
+// Same hook as UseState(), but the previous values are recorded to be undone and redone. The number
+// of steps kept in the history is bounded by the limit (0 for no limit). The values are deep copied
+// into and out of the history, so that modifying a slice or a map in place does not change its
+// steps. The history is changed on the render loop, so its functions can be called from any goroutine.
+func UseHistoryState[T any](initial T, limit int) History[T] {
+	_, file, no, _ := runtime.Caller(1)
+	key := HISTORY_STORE_PREFIX + utils.CallerToKey(file, no)
+	var state any
+	if _, isPresent := store[key]; !isPresent {
+		state = &historyState[T]{present: utils.DeepCopy(initial)}
+	}
+	value, _ := UseStateWithKey(key, state)
+	h := (*value).(*historyState[T])
+	h.limit = limit
+	change := func(update func()) {
+		Update(func() {
+			update()
+			setHasChanged(value, h)
+		})
+	}
+	return History[T]{
+		Value: utils.DeepCopy(h.present),
+		Set: func(v T) {
+			// copied before being modified again by the caller
+			v = utils.DeepCopy(v)
+			change(func() { h.set(v) })
+		},
+		Undo: func() {
+			change(h.undo)
+		},
+		Redo: func() {
+			change(h.redo)
+		},
+		CanUndo: len(h.past) > 0,
+		CanRedo: len(h.future) > 0,
+		Begin: func() {
+			change(func() { h.grouping, h.recorded = true, false })
+		},
+		Commit: func() {
+			change(func() { h.grouping = false })
+		},
+		Clear: func() {
+			change(func() { h.past, h.future = nil, nil })
+		},
+	}
+}
+
+// Records a new value. During a transaction, only the value before its first change is recorded.
+func (h *historyState[T]) set(value T) {
+	if reflect.DeepEqual(h.present, value) {
+		return
+	}
+	if !h.grouping || !h.recorded {
+		h.past = append(h.past, h.present)
+		if h.limit > 0 && len(h.past) > h.limit {
+			h.past = h.past[len(h.past)-h.limit:]
+		}
+		h.recorded = h.grouping
+	}
+	h.present = value
+	h.future = nil
+}
+
+// Restores the value before the last step, and ends the transaction in progress.
+func (h *historyState[T]) undo() {
+	h.grouping = false
+	if len(h.past) == 0 {
+		return
+	}
+	h.future = append(h.future, h.present)
+	h.present = h.past[len(h.past)-1]
+	h.past = h.past[:len(h.past)-1]
+}
+
+// Restores the value of the last undone step.
+func (h *historyState[T]) redo() {
+	if len(h.future) == 0 {
+		return
+	}
+	h.past = append(h.past, h.present)
+	h.present = h.future[len(h.future)-1]
+	h.future = h.future[:len(h.future)-1]
+}
@@ -0,0 +1,280 @@
+// The http package sends HTTP requests through the window.fetch function of the browser,
+// lighter in a Web Assembly binary than net/http. The requests are canceled through an
+// AbortController when their context is done.
+//
+// The requests block until the response is received: they must be sent from a goroutine
+// (as in the fetchers of UseQuery), never directly from a DomBinding.
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// Request describes an HTTP request sent by a Client.
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    []byte
+	Context context.Context
+}
+
+// Response describes the response received for a Request.
+type Response struct {
+	Status     int
+	StatusText string
+	Headers    map[string]string
+	Body       []byte
+}
+
+// Handler sends a request and returns its response.
+type Handler func(req *Request) (*Response, error)
+
+// Interceptor wraps the sending of the requests of a Client: it can modify a request before
+// calling next (to add an authentication token ...), or handle its response.
+type Interceptor func(req *Request, next Handler) (*Response, error)
+
+// StatusError is returned by the JSON helpers when the status of the response is not 2xx.
+type StatusError struct {
+	Response *Response
+}
+
+// Client sends HTTP requests through window.fetch.
+type Client struct {
+	// Prefix of the relative URLs of the requests.
+	BaseURL string
+	// Headers added to all the requests.
+	Headers map[string]string
+	// Maximum duration of a request, including the reading of its body. 0 for no timeout.
+	Timeout time.Duration
+	// Interceptors of the requests, the first one being the outermost.
+	Interceptors []Interceptor
+}
+
+// Client used by the functions of the package.
+var DefaultClient = &Client{}
+
+// Returns the message of the error, with the status of the response.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http: %d %s", e.Response.Status, e.Response.StatusText)
+}
+
+// Returns true if the status of the response is 2xx.
+func (r *Response) OK() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+// Decodes the JSON body of the response into the value passed in parameter.
+func (r *Response) JSON(value any) error {
+	return json.Unmarshal(r.Body, value)
+}
+
+// Returns a client whose relative URLs are prefixed by the base URL.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, Headers: make(map[string]string)}
+}
+
+// Adds interceptors to the client.
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.Interceptors = append(c.Interceptors, interceptors...)
+}
+
+// Sends a request through the interceptors of the client, and returns its response whatever
+// its status. Fails if the request cannot be sent or if its context is done. The request is
+// not modified: the interceptors receive a copy of it, so it can be sent again.
+func (c *Client) Do(req *Request) (*Response, error) {
+	copied := *req
+	req = &copied
+	if req.Context == nil {
+		req.Context = context.Background()
+	}
+	if c.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context, c.Timeout)
+		defer cancel()
+		req.Context = ctx
+	}
+	if c.BaseURL != "" && !strings.Contains(req.URL, "://") && !strings.HasPrefix(req.URL, "data:") {
+		req.URL = strings.TrimSuffix(c.BaseURL, "/") + "/" + strings.TrimPrefix(req.URL, "/")
+	}
+	headers := make(map[string]string)
+	for name, value := range c.Headers {
+		headers[name] = value
+	}
+	for name, value := range req.Headers {
+		headers[name] = value
+	}
+	req.Headers = headers
+	handler := Handler(fetch)
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.Interceptors[i], handler
+		handler = func(req *Request) (*Response, error) { return interceptor(req, next) }
+	}
+	return handler(req)
+}
+
+// Sends a request with a JSON body (if not nil) and decodes the JSON body of its response into
+// the output (if not nil). Returns a StatusError if the status of the response is not 2xx.
+func (c *Client) DoJSON(ctx context.Context, method string, url string, body any, output any) error {
+	req := &Request{Method: method, URL: url, Context: ctx, Headers: map[string]string{dom.HTTP_HEADER_ACCEPT: dom.HTTP_CONTENT_TYPE_JSON}}
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		req.Body = encoded
+		req.Headers[dom.HTTP_HEADER_CONTENT_TYPE] = dom.HTTP_CONTENT_TYPE_JSON
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	if !resp.OK() {
+		return &StatusError{resp}
+	}
+	if output == nil || len(bytes.TrimSpace(resp.Body)) == 0 {
+		return nil
+	}
+	return resp.JSON(output)
+}
+
+// Sends a GET request and decodes its JSON response into the output.
+func (c *Client) Get(ctx context.Context, url string, output any) error {
+	return c.DoJSON(ctx, dom.HTTP_METHOD_GET, url, nil, output)
+}
+
+// Sends a POST request with a JSON body and decodes its JSON response into the output.
+func (c *Client) Post(ctx context.Context, url string, body any, output any) error {
+	return c.DoJSON(ctx, dom.HTTP_METHOD_POST, url, body, output)
+}
+
+// Sends a PUT request with a JSON body and decodes its JSON response into the output.
+func (c *Client) Put(ctx context.Context, url string, body any, output any) error {
+	return c.DoJSON(ctx, dom.HTTP_METHOD_PUT, url, body, output)
+}
+
+// Sends a DELETE request and decodes its JSON response into the output.
+func (c *Client) Delete(ctx context.Context, url string, output any) error {
+	return c.DoJSON(ctx, dom.HTTP_METHOD_DELETE, url, nil, output)
+}
+
+// Sends a GET request with the default client and decodes its JSON response into the output.
+func Get(ctx context.Context, url string, output any) error {
+	return DefaultClient.Get(ctx, url, output)
+}
+
+// Sends a POST request with the default client and decodes its JSON response into the output.
+func Post(ctx context.Context, url string, body any, output any) error {
+	return DefaultClient.Post(ctx, url, body, output)
+}
+
+// Sends a PUT request with the default client and decodes its JSON response into the output.
+func Put(ctx context.Context, url string, body any, output any) error {
+	return DefaultClient.Put(ctx, url, body, output)
+}
+
+// Sends a DELETE request with the default client and decodes its JSON response into the output.
+func Delete(ctx context.Context, url string, output any) error {
+	return DefaultClient.Delete(ctx, url, output)
+}
+
+// Returns an interceptor adding to the requests an 'Authorization: Bearer' header with the
+// token returned by the function, if not empty.
+func BearerToken(token func() string) Interceptor {
+	return func(req *Request, next Handler) (*Response, error) {
+		if t := token(); t != "" {
+			req.Headers[dom.HTTP_HEADER_AUTHORIZATION] = "Bearer " + t
+		}
+		return next(req)
+	}
+}
+
+// Sends a request through window.fetch, aborted when its context is done.
+func fetch(req *Request) (*Response, error) {
+	if err := req.Context.Err(); err != nil {
+		return nil, err
+	}
+	controller := js.Global().Get(dom.JS_ABORT_CONTROLLER).New()
+	headers := make(map[string]any)
+	for name, value := range req.Headers {
+		headers[name] = value
+	}
+	method := req.Method
+	if method == "" {
+		method = dom.HTTP_METHOD_GET
+	}
+	init := map[string]any{
+		dom.JS_METHOD:  method,
+		dom.JS_HEADERS: headers,
+		dom.JS_SIGNAL:  controller.Get(dom.JS_SIGNAL),
+	}
+	if req.Body != nil {
+		body := js.Global().Get(dom.JS_UINT8_ARRAY).New(len(req.Body))
+		js.CopyBytesToJS(body, req.Body)
+		init[dom.JS_BODY] = body
+	}
+	abort := func() { controller.Call(dom.JS_ABORT) }
+	value, err := await(req.Context, js.Global().Call(dom.JS_FETCH, req.URL, init), abort)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Response{
+		Status:     value.Get(dom.JS_STATUS).Int(),
+		StatusText: value.Get(dom.JS_STATUS_TEXT).String(),
+		Headers:    make(map[string]string),
+	}
+	forEach := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		resp.Headers[strings.ToLower(args[1].String())] = args[0].String()
+		return nil
+	})
+	value.Get(dom.JS_HEADERS).Call(dom.JS_FOR_EACH, forEach)
+	forEach.Release()
+	buffer, err := await(req.Context, value.Call(dom.JS_ARRAY_BUFFER), abort)
+	if err != nil {
+		return nil, err
+	}
+	array := js.Global().Get(dom.JS_UINT8_ARRAY).New(buffer)
+	resp.Body = make([]byte, array.Length())
+	js.CopyBytesToGo(resp.Body, array)
+	return resp, nil
+}
+
+// Waits for a promise to be settled, and returns its value or its rejection. If the context is done
+// before, the promise is aborted and the error of the context is returned.
+func await(ctx context.Context, promise js.Value, abort func()) (js.Value, error) {
+	values := make(chan js.Value, 1)
+	errs := make(chan error, 1)
+	onFulfilled := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		values <- args[0]
+		return nil
+	})
+	onRejected := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		errs <- errors.New(js.Global().Get(dom.JS_STRING).Invoke(args[0]).String())
+		return nil
+	})
+	defer onFulfilled.Release()
+	defer onRejected.Release()
+	promise.Call(dom.JS_THEN, onFulfilled, onRejected)
+	select {
+	case value := <-values:
+		return value, nil
+	case err := <-errs:
+		return js.Undefined(), err
+	case <-ctx.Done():
+		abort()
+		// wait for the promise to be settled before releasing its callbacks
+		select {
+		case <-values:
+		case <-errs:
+		}
+		return js.Undefined(), ctx.Err()
+	}
+}
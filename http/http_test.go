@@ -0,0 +1,94 @@
+// GOOS=js GOARCH=wasm go test ./http
+
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type test struct {
+	name     string
+	function func(t *testing.T)
+}
+
+type user struct {
+	Name string `json:"name"`
+}
+
+var tests = []test{
+	{
+		"Get JSON",
+		func(t *testing.T) {
+			output := user{}
+			if err := Get(context.Background(), `data:application/json,{"name":"gooroo"}`, &output); err != nil || output.Name != "gooroo" {
+				t.Errorf("Unexpected response %+v: %v", output, err)
+			}
+		},
+	},
+	{
+		"Interceptors",
+		func(t *testing.T) {
+			client := NewClient("https://example.com/api/")
+			client.Headers["X-Client"] = "gooroo"
+			client.Use(BearerToken(func() string { return "secret" }), func(req *Request, next Handler) (*Response, error) {
+				if req.URL != "https://example.com/api/users" || req.Headers["Authorization"] != "Bearer secret" || req.Headers["X-Client"] != "gooroo" {
+					t.Errorf("Unexpected request %+v", req)
+				}
+				return &Response{Status: 404, StatusText: "Not Found"}, nil
+			})
+			err := client.Get(context.Background(), "/users", nil)
+			statusErr := &StatusError{}
+			if !errors.As(err, &statusErr) || statusErr.Response.Status != 404 {
+				t.Errorf("Expected a status error, got %v", err)
+			}
+		},
+	},
+	{
+		"Resending a request",
+		func(t *testing.T) {
+			client := NewClient("https://example.com/api/")
+			client.Headers["X-Client"] = "gooroo"
+			client.Timeout = time.Minute
+			urls := []string{}
+			client.Use(BearerToken(func() string { return "secret" }), func(req *Request, next Handler) (*Response, error) {
+				urls = append(urls, req.URL)
+				return &Response{Status: 204}, nil
+			})
+			req := &Request{URL: "/users", Headers: map[string]string{"Accept": "application/json"}}
+			for i := 0; i < 2; i++ {
+				if _, err := client.Do(req); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if req.URL != "/users" || len(req.Headers) != 1 || req.Context != nil {
+				t.Errorf("Request modified %+v", req)
+			}
+			if len(urls) != 2 || urls[1] != "https://example.com/api/users" {
+				t.Errorf("Unexpected URLs %v", urls)
+			}
+		},
+	},
+	{
+		"Cancellation",
+		func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := Get(ctx, "data:,", nil); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected a canceled request, got %v", err)
+			}
+			client := &Client{Timeout: time.Nanosecond}
+			if err := client.Get(context.Background(), "data:,", nil); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected a timeout, got %v", err)
+			}
+		},
+	},
+}
+
+func Test_All(t *testing.T) {
+	for _, test := range tests {
+		t.Run(test.name, test.function)
+	}
+}
@@ -0,0 +1,350 @@
+// The idb package stores data offline in the IndexedDB of the browser. The databases are opened
+// with versioned migrations, and their object stores hold typed Go values, encoded in JSON.
+//
+// The calls block until the IndexedDB requests are done: they must be made from a goroutine
+// (as in the fetchers of UseQuery), never directly from a DomBinding.
+package idb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo"
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// Mode of a transaction.
+type Mode string
+
+const READ_ONLY Mode = "readonly"
+const READ_WRITE Mode = "readwrite"
+
+const QUERY_KEY_PREFIX = "idb:"
+
+// ErrBlocked is returned by Open when the database is still open at an older version in another
+// tab, which prevents its upgrade.
+var ErrBlocked = errors.New("idb: open blocked by another connection to the database")
+
+// DB is an open IndexedDB database. It is closed when another tab upgrades it (its next
+// transactions then fail), so as not to block the upgrade.
+type DB struct {
+	Name            string
+	value           js.Value
+	onVersionChange js.Func
+}
+
+// Migration upgrades a database from a version to the next one.
+type Migration func(upgrade *Upgrade) error
+
+// Upgrade gives access to the schema of a database during a migration.
+type Upgrade struct {
+	db js.Value
+	tx js.Value
+}
+
+// StoreOptions describes the keys of an object store.
+type StoreOptions struct {
+	// Path of the key in the values (a field of their JSON encoding), or empty for keys passed to Put.
+	KeyPath string
+	// Generates the keys of the values.
+	AutoIncrement bool
+}
+
+// Opens a database, whose version is the number of migrations. The migrations that have not been
+// applied yet are run in order, from the current version of the database. Fails with ErrBlocked
+// if the database must be upgraded while another tab still uses an older version.
+func Open(name string, migrations ...Migration) (*DB, error) {
+	// the IndexedDB version 1 is the empty schema, as the version 0 is not allowed
+	request, err := call(js.Global().Get(dom.JS_INDEXED_DB), dom.JS_OPEN, name, len(migrations)+1)
+	if err != nil {
+		return nil, err
+	}
+	var upgradeErr error
+	blocked := false
+	onUpgrade := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if blocked {
+			// the request blocked goes on once the other tabs are closed, without its migrations
+			call(request.Get(dom.JS_TRANSACTION), dom.JS_ABORT)
+			return nil
+		}
+		upgrade := &Upgrade{request.Get(dom.JS_RESULT), request.Get(dom.JS_TRANSACTION)}
+		for version := appliedMigrations(args[0].Get(dom.JS_OLD_VERSION).Int()); version < len(migrations); version++ {
+			if upgradeErr = migrations[version](upgrade); upgradeErr != nil {
+				upgradeErr = fmt.Errorf("idb: migration %d: %w", version+1, upgradeErr)
+				call(upgrade.tx, dom.JS_ABORT)
+				break
+			}
+		}
+		return nil
+	})
+	request.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_UPGRADE_NEEDED, onUpgrade)
+	result, err := await(request, dom.JS_EVENT_BLOCKED)
+	if errors.Is(err, ErrBlocked) {
+		// the listener is kept to abort the upgrade of the request
+		blocked = true
+		return nil, err
+	}
+	onUpgrade.Release()
+	if upgradeErr != nil {
+		return nil, upgradeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	db := &DB{Name: name, value: result}
+	db.onVersionChange = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		result.Call(dom.JS_CLOSE)
+		return nil
+	})
+	result.Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_VERSION_CHANGE, db.onVersionChange)
+	return db, nil
+}
+
+// Returns the number of migrations applied to a database, from its IndexedDB version
+// (0 for a new database).
+func appliedMigrations(version int) int {
+	if version == 0 {
+		return 0
+	}
+	return version - 1
+}
+
+// Deletes a database.
+func DeleteDatabase(name string) error {
+	request, err := call(js.Global().Get(dom.JS_INDEXED_DB), dom.JS_DELETE_DATABASE, name)
+	if err != nil {
+		return err
+	}
+	_, err = await(request)
+	return err
+}
+
+// Closes the database.
+func (db *DB) Close() {
+	db.value.Call(dom.JS_CLOSE)
+	db.value.Call(dom.JS_REMOVE_EVENT_LISTENER, dom.JS_EVENT_VERSION_CHANGE, db.onVersionChange)
+	db.onVersionChange.Release()
+}
+
+// Creates an object store.
+func (u *Upgrade) CreateStore(name string, options StoreOptions) error {
+	params := map[string]any{dom.JS_AUTO_INCREMENT: options.AutoIncrement}
+	if options.KeyPath != "" {
+		params[dom.JS_KEY_PATH] = options.KeyPath
+	}
+	_, err := call(u.db, dom.JS_CREATE_OBJECT_STORE, name, params)
+	return err
+}
+
+// Deletes an object store.
+func (u *Upgrade) DeleteStore(name string) error {
+	_, err := call(u.db, dom.JS_DELETE_OBJECT_STORE, name)
+	return err
+}
+
+// Creates an index on an object store, on the path of a field of its values.
+func (u *Upgrade) CreateIndex(store string, name string, keyPath string, unique bool) error {
+	objectStore, err := call(u.tx, dom.JS_OBJECT_STORE, store)
+	if err != nil {
+		return err
+	}
+	_, err = call(objectStore, dom.JS_CREATE_INDEX, name, keyPath, map[string]any{dom.JS_UNIQUE: unique})
+	return err
+}
+
+// Deletes an index of an object store.
+func (u *Upgrade) DeleteIndex(store string, name string) error {
+	objectStore, err := call(u.tx, dom.JS_OBJECT_STORE, store)
+	if err != nil {
+		return err
+	}
+	_, err = call(objectStore, dom.JS_DELETE_INDEX, name)
+	return err
+}
+
+// Tx is a transaction on object stores of a database.
+type Tx struct {
+	db    *DB
+	value js.Value
+}
+
+// Runs the function in a transaction on the object stores. The transaction is aborted if the
+// function returns an error, otherwise it is committed once its requests are done. The queries
+// of the object stores modified by a READ_WRITE transaction are invalidated.
+func (db *DB) Transaction(mode Mode, stores []string, run func(tx *Tx) error) error {
+	names := make([]any, len(stores))
+	for i, store := range stores {
+		names[i] = store
+	}
+	value, err := call(db.value, dom.JS_TRANSACTION, names, string(mode))
+	if err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	listeners := map[string]js.Func{
+		dom.JS_EVENT_COMPLETE: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			done <- nil
+			return nil
+		}),
+		dom.JS_EVENT_ABORT: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			done <- jsError(value.Get(dom.JS_ERROR), "idb: transaction aborted")
+			return nil
+		}),
+	}
+	for event, listener := range listeners {
+		value.Call(dom.JS_ADD_EVENT_LISTENER, event, listener)
+		defer listener.Release()
+	}
+	if err := run(&Tx{db, value}); err != nil {
+		call(value, dom.JS_ABORT)
+		<-done
+		return err
+	}
+	if err := <-done; err != nil {
+		return err
+	}
+	if mode == READ_WRITE {
+		keys := make([]string, len(stores))
+		for i, store := range stores {
+			keys[i] = QueryKey(db, store)
+		}
+		gooroo.InvalidateQueries(keys...)
+	}
+	return nil
+}
+
+// Returns the key of the query of the values of an object store, used by UseStore.
+func QueryKey(db *DB, store string) string {
+	return QUERY_KEY_PREFIX + db.Name + ":" + store
+}
+
+// Calls a method of a javascript value, and returns the exception it throws as an error.
+func call(value js.Value, method string, args ...any) (result js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, isJsErr := r.(js.Error)
+			if !isJsErr {
+				panic(r)
+			}
+			err = jsError(jsErr.Value, "idb: "+method+" failed")
+		}
+	}()
+	return value.Call(method, args...), nil
+}
+
+// Returns the error of a javascript DOMException, or the fallback message.
+func jsError(value js.Value, fallback string) error {
+	if value.Truthy() && value.Get(dom.JS_MESSAGE).Truthy() {
+		return errors.New("idb: " + value.Get(dom.JS_MESSAGE).String())
+	}
+	return errors.New(fallback)
+}
+
+// Waits for a request to succeed, and returns its result or its error. With the 'blocked' event
+// passed in parameter, an open request fails with ErrBlocked when it is blocked.
+func await(request js.Value, events ...string) (js.Value, error) {
+	done := make(chan error, 1)
+	listeners := map[string]js.Func{
+		dom.JS_EVENT_SUCCESS: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			done <- nil
+			return nil
+		}),
+		dom.JS_EVENT_ERROR: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			done <- jsError(request.Get(dom.JS_ERROR), "idb: request failed")
+			return nil
+		}),
+	}
+	for _, event := range events {
+		if event == dom.JS_EVENT_BLOCKED {
+			listeners[event] = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+				done <- ErrBlocked
+				return nil
+			})
+		}
+	}
+	for event, listener := range listeners {
+		request.Call(dom.JS_ADD_EVENT_LISTENER, event, listener)
+	}
+	err := <-done
+	for event, listener := range listeners {
+		request.Call(dom.JS_REMOVE_EVENT_LISTENER, event, listener)
+		listener.Release()
+	}
+	if err != nil {
+		return js.Undefined(), err
+	}
+	return request.Get(dom.JS_RESULT), nil
+}
+
+// Encodes a Go value into a javascript value through JSON.
+func encode(value any) (js.Value, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return js.Undefined(), err
+	}
+	return js.Global().Get(dom.JS_JSON).Call(dom.JS_PARSE, string(encoded)), nil
+}
+
+// Decodes a javascript value into a Go value through JSON.
+func decode(value js.Value, target any) error {
+	return json.Unmarshal([]byte(js.Global().Get(dom.JS_JSON).Call(dom.JS_STRINGIFY, value).String()), target)
+}
+
+// Converts a javascript key to a Go value: a string, a float64, a time.Time for a Date or a []any
+// for an array.
+func goKey(key js.Value) any {
+	switch {
+	case key.Type() == js.TypeString:
+		return key.String()
+	case key.Type() == js.TypeNumber:
+		return key.Float()
+	case key.InstanceOf(js.Global().Get(dom.JS_DATE)):
+		return time.UnixMilli(int64(key.Call(dom.JS_GET_TIME).Float()))
+	case js.Global().Get(dom.JS_ARRAY).Call(dom.JS_IS_ARRAY, key).Bool():
+		elements := make([]any, key.Length())
+		for i := range elements {
+			elements[i] = goKey(key.Index(i))
+		}
+		return elements
+	case key.Type() == js.TypeObject:
+		// binary keys (ArrayBuffer ...)
+		var decoded any
+		decode(key, &decoded)
+		return decoded
+	}
+	return nil
+}
+
+// Converts a Go key to a javascript key: a string, a number, a time.Time (a Date), or a slice or
+// array of them (an Array). Returns an error for the other types, which are not valid keys.
+func jsKey(key any) (js.Value, error) {
+	if date, isDate := key.(time.Time); isDate {
+		return js.Global().Get(dom.JS_DATE).New(date.UnixMilli()), nil
+	}
+	value := reflect.ValueOf(key)
+	switch value.Kind() {
+	case reflect.String:
+		return js.ValueOf(value.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return js.ValueOf(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return js.ValueOf(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return js.ValueOf(value.Float()), nil
+	case reflect.Slice, reflect.Array:
+		elements := make([]any, value.Len())
+		for i := range elements {
+			element, err := jsKey(value.Index(i).Interface())
+			if err != nil {
+				return js.Undefined(), err
+			}
+			elements[i] = element
+		}
+		return js.ValueOf(elements), nil
+	}
+	return js.Undefined(), fmt.Errorf("idb: unsupported key type %T", key)
+}
@@ -0,0 +1,152 @@
+// GOOS=js GOARCH=wasm go test ./idb
+// The round trip through IndexedDB runs only in a browser (with wasmbrowsertest for example).
+
+package idb
+
+import (
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+type test struct {
+	name     string
+	function func(t *testing.T)
+}
+
+type note struct {
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+var tests = []test{
+	{
+		"Encode & decode",
+		func(t *testing.T) {
+			encoded, err := encode(note{1, "offline"})
+			if err != nil || encoded.Get("title").String() != "offline" || encoded.Get("id").Int() != 1 {
+				t.Errorf("Value not encoded as an object: %v", err)
+			}
+			decoded := note{}
+			if err := decode(encoded, &decoded); err != nil || decoded.Title != "offline" {
+				t.Errorf("Value not decoded: %+v %v", decoded, err)
+			}
+		},
+	},
+	{
+		"Keys",
+		func(t *testing.T) {
+			if goKey(js.ValueOf("a")) != "a" || goKey(js.ValueOf(2)) != 2.0 {
+				t.Error("Unexpected scalar keys")
+			}
+			date := js.Global().Get("Date").New(1000)
+			if compound, _ := goKey(js.ValueOf([]any{"a", 1, date})).([]any); len(compound) != 3 || compound[0] != "a" || compound[1] != 1.0 {
+				t.Error("Unexpected compound key")
+			} else if key, _ := compound[2].(time.Time); !key.Equal(time.UnixMilli(1000)) {
+				t.Errorf("Date not converted to a time: %v", compound[2])
+			}
+		},
+	},
+	{
+		"Go keys",
+		func(t *testing.T) {
+			if key, err := jsKey([]string{"notes", "2024"}); err != nil || key.Length() != 2 || key.Index(1).String() != "2024" {
+				t.Errorf("String slice not converted to an array: %v", err)
+			}
+			if key, err := jsKey(uint8(7)); err != nil || key.Int() != 7 {
+				t.Errorf("Number not converted: %v", err)
+			}
+			if key, err := jsKey(time.UnixMilli(1000)); err != nil || key.Call("getTime").Int() != 1000 {
+				t.Errorf("Time not converted to a date: %v", err)
+			}
+			if _, err := jsKey([]any{"a", note{}}); err == nil {
+				t.Error("Expected an error for a struct key")
+			}
+			if _, err := jsKey(nil); err == nil {
+				t.Error("Expected an error for a nil key")
+			}
+		},
+	},
+	{
+		"Round trip",
+		func(t *testing.T) {
+			if js.Global().Get("indexedDB").IsUndefined() {
+				t.Skip("IndexedDB only available in a browser")
+			}
+			db, err := Open("gooroo-test", func(u *Upgrade) error {
+				return u.CreateStore("notes", StoreOptions{})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer DeleteDatabase("gooroo-test")
+			defer db.Close()
+			notes := NewStore[note](db, "notes")
+			if _, err := notes.Put(note{1, "offline"}, []string{"inbox", "1"}); err != nil {
+				t.Fatal(err)
+			}
+			if value, isPresent, err := notes.Get([]string{"inbox", "1"}); err != nil || !isPresent || value.Title != "offline" {
+				t.Errorf("Unexpected value %+v %v", value, err)
+			}
+			if _, err := notes.Put(note{2, "invalid"}, note{}); err == nil {
+				t.Error("Expected an error for a struct key")
+			}
+			if err := notes.Delete([]string{"inbox", "1"}); err != nil {
+				t.Fatal(err)
+			}
+			if count, err := notes.Count(); err != nil || count != 0 {
+				t.Errorf("Value not deleted: %d %v", count, err)
+			}
+		},
+	},
+	{
+		"Blocked & version change",
+		func(t *testing.T) {
+			defer js.Global().Delete("indexedDB")
+			js.Global().Set("indexedDB", js.Global().Get("Function").New(`
+				const target = () => Object.assign(new EventTarget(), { close() { this.closed = true; } });
+				return { open: () => {
+					const request = globalThis.lastRequest = target();
+					setTimeout(() => request.dispatchEvent(new Event(globalThis.openEvent)));
+					request.result = target();
+					return request;
+				} };
+			`).Invoke())
+			js.Global().Set("openEvent", "blocked")
+			if _, err := Open("blocked"); err != ErrBlocked {
+				t.Errorf("Expected ErrBlocked: %v", err)
+			}
+			js.Global().Set("openEvent", "success")
+			db, err := Open("opened")
+			if err != nil {
+				t.Fatal(err)
+			}
+			db.value.Call("dispatchEvent", js.Global().Get("Event").New("versionchange"))
+			if !db.value.Get("closed").Truthy() {
+				t.Error("Database not closed on a version change")
+			}
+			db.Close()
+		},
+	},
+	{
+		"Migrations & errors",
+		func(t *testing.T) {
+			if appliedMigrations(0) != 0 || appliedMigrations(1) != 0 || appliedMigrations(3) != 2 {
+				t.Error("Unexpected number of applied migrations")
+			}
+			thrower := js.Global().Get("Function").New(`return { fail: () => { throw new Error("quota exceeded") } }`).Invoke()
+			if _, err := call(thrower, "fail"); err == nil || err.Error() != "idb: quota exceeded" {
+				t.Errorf("Exception not returned as an error: %v", err)
+			}
+			if err := jsError(js.Null(), "idb: fallback"); err.Error() != "idb: fallback" {
+				t.Error("Unexpected fallback error")
+			}
+		},
+	},
+}
+
+func Test_All(t *testing.T) {
+	for _, test := range tests {
+		t.Run(test.name, test.function)
+	}
+}
@@ -0,0 +1,243 @@
+package idb
+
+import (
+	"context"
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo"
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// Store is an object store holding values of type T. Its methods run in the transaction of
+// the store, or each one in its own transaction.
+type Store[T any] struct {
+	db   *DB
+	tx   *Tx
+	name string
+}
+
+// Index is an index of an object store holding values of type T.
+type Index[T any] struct {
+	store *Store[T]
+	name  string
+}
+
+// Returns an object store of the database, whose methods each run in their own transaction.
+func NewStore[T any](db *DB, name string) *Store[T] {
+	return &Store[T]{db, nil, name}
+}
+
+// Returns an object store of a transaction. The store must be in the scope of the transaction.
+func TxStore[T any](tx *Tx, name string) *Store[T] {
+	return &Store[T]{tx.db, tx, name}
+}
+
+// Runs a request on the javascript object store (or an index of it) in the transaction of the
+// store, or in a new transaction.
+func (s *Store[T]) run(mode Mode, request func(objectStore js.Value) error) error {
+	if s.tx != nil {
+		objectStore, err := call(s.tx.value, dom.JS_OBJECT_STORE, s.name)
+		if err != nil {
+			return err
+		}
+		return request(objectStore)
+	}
+	return s.db.Transaction(mode, []string{s.name}, func(tx *Tx) error {
+		return TxStore[T](tx, s.name).run(mode, request)
+	})
+}
+
+// Sends a request to a javascript object store or index, and waits for its result.
+func send(target js.Value, method string, args ...any) (js.Value, error) {
+	request, err := call(target, method, args...)
+	if err != nil {
+		return js.Undefined(), err
+	}
+	return await(request)
+}
+
+// Returns the value of the key, and false if the store has no value for it. The keys are
+// strings, numbers, time.Time or slices of them: other types return an error.
+func (s *Store[T]) Get(key any) (value T, isPresent bool, err error) {
+	err = s.run(READ_ONLY, func(objectStore js.Value) error {
+		value, isPresent, err = get[T](objectStore, key)
+		return err
+	})
+	return value, isPresent, err
+}
+
+// Adds or replaces a value, under the key passed in parameter if the store has no key path.
+// Returns the key of the value.
+func (s *Store[T]) Put(value T, key ...any) (storedKey any, err error) {
+	encoded, err := encode(value)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{encoded}
+	if len(key) > 0 {
+		encodedKey, err := jsKey(key[0])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, encodedKey)
+	}
+	err = s.run(READ_WRITE, func(objectStore js.Value) error {
+		result, err := send(objectStore, dom.JS_PUT, args...)
+		storedKey = goKey(result)
+		return err
+	})
+	return storedKey, err
+}
+
+// Deletes the value of the key.
+func (s *Store[T]) Delete(key any) error {
+	encodedKey, err := jsKey(key)
+	if err != nil {
+		return err
+	}
+	return s.run(READ_WRITE, func(objectStore js.Value) error {
+		_, err := send(objectStore, dom.JS_DELETE, encodedKey)
+		return err
+	})
+}
+
+// Deletes all the values of the store.
+func (s *Store[T]) Clear() error {
+	return s.run(READ_WRITE, func(objectStore js.Value) error {
+		_, err := send(objectStore, dom.JS_CLEAR)
+		return err
+	})
+}
+
+// Returns all the values of the store, in the order of their keys.
+func (s *Store[T]) All() (values []T, err error) {
+	err = s.run(READ_ONLY, func(objectStore js.Value) error {
+		values, err = getAll[T](objectStore)
+		return err
+	})
+	return values, err
+}
+
+// Returns the number of values of the store.
+func (s *Store[T]) Count() (count int, err error) {
+	err = s.run(READ_ONLY, func(objectStore js.Value) error {
+		result, err := send(objectStore, dom.JS_COUNT)
+		if err == nil {
+			count = result.Int()
+		}
+		return err
+	})
+	return count, err
+}
+
+// Iterates with a cursor over the values of the store, in the order of their keys, until the
+// callback returns false.
+func (s *Store[T]) Each(callback func(key any, value T) bool) error {
+	return s.run(READ_ONLY, func(objectStore js.Value) error {
+		return each(objectStore, callback)
+	})
+}
+
+// Returns an index of the store.
+func (s *Store[T]) Index(name string) *Index[T] {
+	return &Index[T]{s, name}
+}
+
+// Runs a request on the javascript index.
+func (i *Index[T]) run(request func(index js.Value) error) error {
+	return i.store.run(READ_ONLY, func(objectStore js.Value) error {
+		index, err := call(objectStore, dom.JS_INDEX, i.name)
+		if err != nil {
+			return err
+		}
+		return request(index)
+	})
+}
+
+// Returns the first value whose indexed field equals the key, and false if there is none.
+func (i *Index[T]) Get(key any) (value T, isPresent bool, err error) {
+	err = i.run(func(index js.Value) error {
+		value, isPresent, err = get[T](index, key)
+		return err
+	})
+	return value, isPresent, err
+}
+
+// Returns all the values whose indexed field equals the key, or all the values of the store in the
+// order of the index if no key is passed.
+func (i *Index[T]) All(key ...any) (values []T, err error) {
+	err = i.run(func(index js.Value) error {
+		values, err = getAll[T](index, key...)
+		return err
+	})
+	return values, err
+}
+
+// Iterates with a cursor over the values of the store in the order of the index, until the
+// callback returns false. The callback receives the indexed field of the values.
+func (i *Index[T]) Each(callback func(key any, value T) bool) error {
+	return i.run(func(index js.Value) error {
+		return each(index, callback)
+	})
+}
+
+// Returns the value of a key of a javascript object store or index.
+func get[T any](target js.Value, key any) (value T, isPresent bool, err error) {
+	encodedKey, err := jsKey(key)
+	if err != nil {
+		return value, false, err
+	}
+	result, err := send(target, dom.JS_GET, encodedKey)
+	if err != nil || result.IsUndefined() {
+		return value, false, err
+	}
+	return value, true, decode(result, &value)
+}
+
+// Returns the values of a javascript object store or index, matching the key if passed.
+func getAll[T any](target js.Value, key ...any) ([]T, error) {
+	args := []any{}
+	if len(key) > 0 {
+		encodedKey, err := jsKey(key[0])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, encodedKey)
+	}
+	result, err := send(target, dom.JS_GET_ALL, args...)
+	if err != nil {
+		return nil, err
+	}
+	values := []T{}
+	return values, decode(result, &values)
+}
+
+// Iterates with a cursor over the values of a javascript object store or index.
+func each[T any](target js.Value, callback func(key any, value T) bool) error {
+	request, err := call(target, dom.JS_OPEN_CURSOR)
+	if err != nil {
+		return err
+	}
+	for {
+		cursor, err := await(request)
+		if err != nil || cursor.IsNull() {
+			return err
+		}
+		var value T
+		if err := decode(cursor.Get(dom.JS_VALUE), &value); err != nil {
+			return err
+		}
+		if !callback(goKey(cursor.Get(dom.JS_KEY)), value) {
+			return nil
+		}
+		cursor.Call(dom.JS_CONTINUE)
+	}
+}
+
+// Returns all the values of an object store as a query, fetched again after each READ_WRITE
+// transaction on the store.
+func UseStore[T any](db *DB, store string, options ...gooroo.QueryOption) gooroo.Query[[]T] {
+	return gooroo.UseQuery(QueryKey(db, store), func(ctx context.Context) ([]T, error) {
+		return NewStore[T](db, store).All()
+	}, options...)
+}
@@ -0,0 +1,92 @@
+// The mathml package gathers the DomComponents of the MathML elements, to write mathematical
+// formulas. The content of a <math> is parsed in the MathML namespace.
+package mathml
+
+import (
+	"github.com/Matbabs/Gooroo"
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// MathmlElements
+
+// Declare a mathml element with the <math> tag, root of a formula.
+func Math(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MATH, insiders...)
+}
+
+// Declare a mathml element with the <mrow> tag, to group sub-expressions.
+func Mrow(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MROW, insiders...)
+}
+
+// Declare a mathml element with the <mi> tag, for an identifier.
+func Mi[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MI, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare a mathml element with the <mn> tag, for a number.
+func Mn[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MN, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare a mathml element with the <mo> tag, for an operator.
+func Mo[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MO, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare a mathml element with the <mtext> tag, for a text.
+func Mtext[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MTEXT, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare a mathml element with the <mfrac> tag, for a fraction of its two children.
+func Mfrac(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MFRAC, insiders...)
+}
+
+// Declare a mathml element with the <msqrt> tag, for a square root.
+func Msqrt(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MSQRT, insiders...)
+}
+
+// Declare a mathml element with the <mroot> tag, for a root with an index.
+func Mroot(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MROOT, insiders...)
+}
+
+// Declare a mathml element with the <msup> tag, for a superscript.
+func Msup(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MSUP, insiders...)
+}
+
+// Declare a mathml element with the <msub> tag, for a subscript.
+func Msub(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MSUB, insiders...)
+}
+
+// Declare a mathml element with the <msubsup> tag, for a subscript and a superscript.
+func Msubsup(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MSUBSUP, insiders...)
+}
+
+// Declare a mathml element with the <mtable> tag, for a matrix.
+func Mtable(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MTABLE, insiders...)
+}
+
+// Declare a mathml element with the <mtr> tag, for a row of a matrix.
+func Mtr(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MTR, insiders...)
+}
+
+// Declare a mathml element with the <mtd> tag, for a cell of a matrix.
+func Mtd(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.MATHML_MTD, insiders...)
+}
+
+// MathmlParams
+
+// Declare the attribute 'display=' of a <math>, "block" or "inline".
+func Display(display string) gooroo.DomComponent {
+	return gooroo.Attr(dom.MATHML_PARAM_DISPLAY, display)
+}
@@ -0,0 +1,231 @@
+package gooroo
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Mutations
+
+// The mutations run the writes of the application asynchronously (a POST request to a server ...).
+// They can update optimistically the data of the queries, rolled back if the write fails, and
+// invalidate the queries whose data are modified by the write.
+
+// Mutation describes the state of the write run by UseMutation.
+type Mutation[V any, R any] struct {
+	// Runs the write with the variables passed in parameter. Can be called from any goroutine.
+	Mutate func(variables V)
+	// True while the write is running.
+	Pending bool
+	// Result of the last successful write.
+	Data R
+	// Error returned by the last write, or nil.
+	Error error
+	// True if the last write returned no error.
+	Succeeded bool
+	// Forgets the state of the last write.
+	Reset func()
+}
+
+// MutationOptions describes the effects of a mutation on the queries.
+type MutationOptions[V any, R any] struct {
+	// Called before the write, typically to update the data of the queries optimistically with
+	// SetQueryData. The returned function, if not nil, is called if the write fails.
+	OnMutate func(variables V) (rollback func())
+	// Called with the result of a successful write.
+	OnSuccess func(result R, variables V)
+	// Called with the error of a failed write, after the rollback.
+	OnError func(err error, variables V)
+	// Keys of the queries invalidated once the write is done, successful or not.
+	Invalidate []string
+}
+
+// MutationEntry retains the state of a mutation across the renderings.
+type mutationEntry struct {
+	pending    bool
+	data       any
+	err        error
+	succeeded  bool
+	cancel     context.CancelFunc
+	generation int
+}
+
+const MUTATION_RESOURCE_PREFIX = "mutation:"
+
+// Store of the mutations, by position of their hook.
+var mutations = make(map[string]*mutationEntry)
+
+// Returns a mutation running the write passed in parameter in a goroutine. The state of the
+// mutation and the effects of the options are applied on the render loop. Several options are
+// merged: all their functions are called, in order, and all their queries are invalidated.
+// The context of the write is canceled when the mutation is run again or reset, and its result
+// is then ignored. A write in progress when the component is unmounted goes on, and the effects
+// of its options are still applied.
+func UseMutation[V any, R any](mutator func(ctx context.Context, variables V) (R, error), options ...MutationOptions[V, R]) Mutation[V, R] {
+	_, file, no, _ := runtime.Caller(1)
+	key := utils.CallerToKey(file, no)
+	utils.MapInit(key, mutations, &mutationEntry{})
+	entry := mutations[key]
+	useResource(MUTATION_RESOURCE_PREFIX+key, func() {
+		delete(mutations, key)
+	})
+	option := mergeMutationOptions(options...)
+	mutation := Mutation[V, R]{
+		Mutate: func(variables V) {
+			Update(func() { mutate(entry, mutator, option, variables) })
+		},
+		Pending:   entry.pending,
+		Error:     entry.err,
+		Succeeded: entry.succeeded,
+		Reset: func() {
+			Update(func() {
+				entry.stop()
+				entry.pending, entry.data, entry.err, entry.succeeded = false, nil, nil, false
+			})
+		},
+	}
+	mutation.Data, _ = entry.data.(R)
+	return mutation
+}
+
+// Merges the options of a mutation into one.
+func mergeMutationOptions[V any, R any](options ...MutationOptions[V, R]) MutationOptions[V, R] {
+	if len(options) == 1 {
+		return options[0]
+	}
+	merged := MutationOptions[V, R]{}
+	for _, option := range options {
+		merged.Invalidate = append(merged.Invalidate, option.Invalidate...)
+	}
+	merged.OnMutate = func(variables V) func() {
+		rollbacks := []func(){}
+		for _, option := range options {
+			if option.OnMutate != nil {
+				if rollback := option.OnMutate(variables); rollback != nil {
+					rollbacks = append(rollbacks, rollback)
+				}
+			}
+		}
+		return func() {
+			// restores the data in the reverse order of their updates
+			for i := len(rollbacks) - 1; i >= 0; i-- {
+				rollbacks[i]()
+			}
+		}
+	}
+	merged.OnSuccess = func(result R, variables V) {
+		for _, option := range options {
+			if option.OnSuccess != nil {
+				option.OnSuccess(result, variables)
+			}
+		}
+	}
+	merged.OnError = func(err error, variables V) {
+		for _, option := range options {
+			if option.OnError != nil {
+				option.OnError(err, variables)
+			}
+		}
+	}
+	return merged
+}
+
+// Cancels the write in progress of a mutation, whose result is no longer applied.
+func (entry *mutationEntry) stop() {
+	entry.generation++
+	if entry.cancel != nil {
+		entry.cancel()
+		entry.cancel = nil
+	}
+}
+
+// Runs a write in a goroutine after the optimistic updates of the options, and applies its result
+// and the effects of the options on the render loop, unless the mutation has been reset or run
+// again meanwhile.
+func mutate[V any, R any](entry *mutationEntry, mutator func(ctx context.Context, variables V) (R, error), option MutationOptions[V, R], variables V) {
+	entry.stop()
+	generation := entry.generation
+	ctx, cancel := context.WithCancel(context.Background())
+	entry.cancel = cancel
+	entry.pending, entry.err, entry.succeeded = true, nil, false
+	var rollback func()
+	if option.OnMutate != nil {
+		rollback = option.OnMutate(variables)
+	}
+	go func() {
+		result, err := mutator(ctx, variables)
+		cancel()
+		Update(func() {
+			defer InvalidateQueries(option.Invalidate...)
+			// the write superseded by another one or by a reset (and canceled by it) is ignored:
+			// its rollback would overwrite the optimistic update of the next write
+			if generation != entry.generation {
+				return
+			}
+			if err != nil {
+				if rollback != nil {
+					rollback()
+				}
+				if option.OnError != nil {
+					option.OnError(err, variables)
+				}
+			} else if option.OnSuccess != nil {
+				option.OnSuccess(result, variables)
+			}
+			entry.pending, entry.err, entry.succeeded = false, err, err == nil
+			if err == nil {
+				entry.data = result
+			}
+		})
+	}()
+}
+
+// Returns the data of a query from the cache, and true if the query has data of type T.
+// Must be called on the render loop: during a rendering, in a DomBinding or in an Update().
+func GetQueryData[T any](key string) (T, bool) {
+	var data T
+	entry, isPresent := queries[key]
+	if !isPresent || !entry.hasData {
+		return data, false
+	}
+	data, isTyped := entry.data.(T)
+	return data, isTyped
+}
+
+// Replaces the data of a query in the cache, typically to update it optimistically before a write.
+// Its fetch in progress is canceled so as not to overwrite the new data. Returns a function
+// restoring the previous data. Must be called on the render loop: during a rendering,
+// in a DomBinding or in an Update().
+func SetQueryData[T any](key string, data T) (rollback func()) {
+	utils.MapInit(key, queries, &queryEntry{})
+	entry := queries[key]
+	cancelQuery(entry)
+	previous, hadData := entry.data, entry.hasData
+	entry.data, entry.hasData = data, true
+	updateState()
+	return func() {
+		entry.data, entry.hasData = previous, hadData
+		updateState()
+	}
+}
+
+// Marks the queries as stale and fetches again the ones used by the application. The others are
+// fetched when they are used again. Can be called from any goroutine.
+func InvalidateQueries(keys ...string) {
+	Update(func() {
+		for _, key := range keys {
+			entry, isPresent := queries[key]
+			if !isPresent {
+				continue
+			}
+			entry.updatedAt = time.Time{}
+			if isMounted(queryResourceKey(key)) {
+				cancelQuery(entry)
+				fetchQuery(entry)
+			}
+		}
+	})
+}
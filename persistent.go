@@ -0,0 +1,154 @@
+package gooroo
+
+import (
+	"encoding/json"
+	"errors"
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Persistent state
+
+// Storage identifies a Web Storage of the browser.
+type Storage string
+
+const LOCAL_STORAGE Storage = "localStorage"
+const SESSION_STORAGE Storage = "sessionStorage"
+
+const PERSISTENT_STORE_PREFIX = "persistent:"
+
+// PersistentState describes a variable of the store persisted in a Web Storage.
+type persistentState struct {
+	storage Storage
+	key     string
+	initial any
+	decode  func(data string) (any, error)
+}
+
+var (
+	errStorageUnavailable = errors.New("storage unavailable")
+	errStorageMissing     = errors.New("value not stored")
+
+	// List of the persistent variables, by key in the store.
+	persistentStates = make(map[string]*persistentState)
+
+	// Listener of the changes of the Web Storages made by the other tabs.
+	storageListener js.Func
+)
+
+// Same hook as UseState(), but the value is persisted in JSON in the Web Storage (LOCAL_STORAGE or
+// SESSION_STORAGE) under the key passed in parameter. The value is read from the storage during the
+// first rendering, or is the initial value if it is not stored (or cannot be decoded). The changes
+// of the value made by the other tabs of the application are applied through the 'storage' event.
+// All the calls with the same key share the same value.
+func UsePersistentState[T any](key string, initial T, storage Storage) (actualValue *any, f func(setterValue T)) {
+	storeKey := PERSISTENT_STORE_PREFIX + string(storage) + ":" + key
+	decode := func(data string) (any, error) {
+		var value T
+		err := json.Unmarshal([]byte(data), &value)
+		return value, err
+	}
+	if _, isPresent := persistentStates[storeKey]; !isPresent {
+		persistentStates[storeKey] = &persistentState{storage, key, initial, decode}
+		listenStorage()
+	}
+	hydrated := any(initial)
+	if _, isPresent := store[storeKey]; !isPresent {
+		if value, err := readStorage(storage, key, decode); err == nil {
+			hydrated = value
+		}
+	}
+	value, set := UseStateWithKey(storeKey, hydrated)
+	return value, func(setterValue T) {
+		// the value of a failed write is only kept in memory
+		_ = writeStorage(storage, key, setterValue)
+		set(setterValue)
+	}
+}
+
+// Returns the Web Storage of the browser, or undefined if it is not available. Reading it throws a
+// SecurityError when the storage is disabled (in a sandboxed iframe ...): it is read with
+// Reflect.get, whose exception can be recovered, unlike the one of a property read.
+func webStorage(storage Storage) js.Value {
+	value, err := callStorage(js.Global().Get(dom.JS_REFLECT), dom.JS_GET, js.Global(), string(storage))
+	if err != nil {
+		return js.Undefined()
+	}
+	return value
+}
+
+// Calls a method of a Web Storage, and returns the exception it throws (SecurityError,
+// QuotaExceededError when the storage is full ...) as an error.
+func callStorage(value js.Value, method string, args ...any) (result js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, isJsErr := r.(js.Error)
+			if !isJsErr {
+				panic(r)
+			}
+			err = jsErr
+		}
+	}()
+	return value.Call(method, args...), nil
+}
+
+// Reads and decodes a value of a Web Storage. Fails if the value is not stored or cannot be decoded.
+func readStorage(storage Storage, key string, decode func(data string) (any, error)) (any, error) {
+	if !webStorage(storage).Truthy() {
+		return nil, errStorageUnavailable
+	}
+	data, err := callStorage(webStorage(storage), dom.JS_GET_ITEM, key)
+	if err != nil {
+		return nil, err
+	}
+	if data.IsNull() {
+		return nil, errStorageMissing
+	}
+	return decode(data.String())
+}
+
+// Encodes and writes a value in a Web Storage. Fails if the storage is not available or full.
+func writeStorage(storage Storage, key string, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if !webStorage(storage).Truthy() {
+		return errStorageUnavailable
+	}
+	_, err = callStorage(webStorage(storage), dom.JS_SET_ITEM, key, string(encoded))
+	return err
+}
+
+// Adds the listener applying the changes of the Web Storages made by the other tabs to the
+// persistent variables, if it is not listened yet.
+func listenStorage() {
+	if storageListener.Truthy() {
+		return
+	}
+	storageListener = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		key, newValue, area := args[0].Get(dom.JS_KEY), args[0].Get(dom.JS_NEW_VALUE), args[0].Get(dom.JS_STORAGE_AREA)
+		Update(func() {
+			for storeKey, state := range persistentStates {
+				// a null key means that the whole storage has been cleared
+				if !area.Equal(webStorage(state.storage)) || (!key.IsNull() && key.String() != state.key) {
+					continue
+				}
+				value := state.initial
+				if !newValue.IsNull() {
+					decoded, err := state.decode(newValue.String())
+					if err != nil {
+						continue
+					}
+					value = decoded
+				}
+				utils.MapInit(storeKey, store, &domStore{value, false})
+				setHasChanged(&store[storeKey].value, value)
+			}
+		})
+		return nil
+	})
+	js.Global().Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_STORAGE, storageListener)
+}
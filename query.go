@@ -0,0 +1,175 @@
+package gooroo
+
+import (
+	"context"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Queries
+
+// The queries fetch data asynchronously and keep them in a cache shared by the whole application,
+// identified by a query key. The entries of the cache are only modified on the render loop:
+// the results of the fetches are applied through Update().
+
+// Query describes the state of the data fetched by UseQuery.
+type Query[T any] struct {
+	// Data fetched by the last successful fetch, kept while the query is refetched.
+	Data T
+	// True while the query is fetched for the first time, without data yet.
+	Loading bool
+	// True while the query is fetched, including the revalidations of the cached data.
+	Fetching bool
+	// Error returned by the last fetch, or nil.
+	Error error
+	// Fetches the query again, unless it is already being fetched.
+	Refetch func()
+}
+
+// QueryOption modifies the behavior of a query.
+type QueryOption func(options *queryOptions)
+
+// Options of a query.
+type queryOptions struct {
+	staleTime      time.Duration
+	refetchOnFocus bool
+}
+
+// QueryEntry is an entry of the cache of the queries.
+type queryEntry struct {
+	fetcher    func(ctx context.Context) (any, error)
+	options    queryOptions
+	data       any
+	hasData    bool
+	err        error
+	updatedAt  time.Time
+	fetching   bool
+	cancel     context.CancelFunc
+	generation int
+}
+
+const QUERY_RESOURCE_PREFIX = "query:"
+
+var (
+	// Cache of the queries, by query key.
+	queries = make(map[string]*queryEntry)
+
+	// Listener refetching the stale queries when the window gets the focus.
+	focusListener js.Func
+)
+
+// Sets the duration during which the fetched data are fresh: they are not fetched again when
+// a component using them is mounted or when the window gets the focus. 0 by default.
+func StaleTime(duration time.Duration) QueryOption {
+	return func(options *queryOptions) { options.staleTime = duration }
+}
+
+// Enables or disables the fetch of the stale data when the window gets the focus. Enabled by default.
+func RefetchOnFocus(enabled bool) QueryOption {
+	return func(options *queryOptions) { options.refetchOnFocus = enabled }
+}
+
+// Returns the state of the data identified by the query key, fetched by the fetcher in a goroutine.
+// The data are kept in a cache shared by all the queries with the same key: a query being fetched
+// is not fetched twice, and the cached data are returned while they are revalidated when stale.
+// The context of the fetcher is canceled if no component uses the query anymore.
+func UseQuery[T any](key string, fetcher func(ctx context.Context) (T, error), options ...QueryOption) Query[T] {
+	entry := useQueryEntry(key, func(ctx context.Context) (any, error) { return fetcher(ctx) }, options...)
+	query := Query[T]{
+		Loading:  entry.fetching && !entry.hasData,
+		Fetching: entry.fetching,
+		Error:    entry.err,
+		Refetch: func() {
+			Update(func() { fetchQuery(entry) })
+		},
+	}
+	query.Data, _ = entry.data.(T)
+	return query
+}
+
+// Returns the entry of a query used by the rendering in progress, and fetches it if it has no data
+// yet or if it is stale when mounted.
+func useQueryEntry(key string, fetcher func(ctx context.Context) (any, error), options ...QueryOption) *queryEntry {
+	listenFocus()
+	utils.MapInit(key, queries, &queryEntry{})
+	entry := queries[key]
+	entry.fetcher = fetcher
+	entry.options = queryOptions{0, true}
+	for _, option := range options {
+		option(&entry.options)
+	}
+	mounting := useResource(queryResourceKey(key), func() { cancelQuery(entry) })
+	if (!entry.hasData && entry.err == nil) || (mounting && entry.stale()) {
+		fetchQuery(entry)
+	}
+	return entry
+}
+
+// Returns true if the data of the query have to be fetched again.
+func (entry *queryEntry) stale() bool {
+	return !entry.hasData || time.Since(entry.updatedAt) >= entry.options.staleTime
+}
+
+// Fetches a query in a goroutine, unless it is already being fetched. The result is applied
+// on the render loop, if the fetch has not been canceled or replaced meanwhile.
+func fetchQuery(entry *queryEntry) {
+	if entry.fetching || entry.fetcher == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	entry.generation++
+	generation := entry.generation
+	entry.fetching, entry.cancel = true, cancel
+	fetcher := entry.fetcher
+	go func() {
+		data, err := fetcher(ctx)
+		Update(func() {
+			defer cancel()
+			if ctx.Err() != nil || generation != entry.generation {
+				return
+			}
+			entry.fetching = false
+			if err != nil {
+				entry.err = err
+				return
+			}
+			entry.data, entry.hasData, entry.err, entry.updatedAt = data, true, nil, time.Now()
+		})
+	}()
+}
+
+// Cancels the fetch in progress of a query.
+func cancelQuery(entry *queryEntry) {
+	if entry.fetching {
+		entry.cancel()
+		entry.fetching = false
+		entry.generation++
+	}
+}
+
+// Returns the key of the resource of a query, used by the components using the query.
+func queryResourceKey(key string) string {
+	return QUERY_RESOURCE_PREFIX + key
+}
+
+// Adds the listener refetching the stale mounted queries when the window gets the focus,
+// if it is not listened yet.
+func listenFocus() {
+	if focusListener.Truthy() {
+		return
+	}
+	focusListener = js.FuncOf(func(_ js.Value, _ []js.Value) any {
+		Update(func() {
+			for key, entry := range queries {
+				if isMounted(queryResourceKey(key)) && entry.options.refetchOnFocus && entry.stale() {
+					fetchQuery(entry)
+				}
+			}
+		})
+		return nil
+	})
+	js.Global().Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_FOCUS, focusListener)
+}
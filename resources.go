@@ -0,0 +1,50 @@
+package gooroo
+
+import "github.com/Matbabs/Gooroo/utils"
+
+// Resources
+
+// The resources are held by the hooks across the renderings (the fetch of a query, a socket ...).
+// A resource is used by a rendering when its hook is called, and is released at the end of the
+// first rendering that no longer calls it, as when its component is unmounted.
+
+// Resource describes the use of a resource by the renderings.
+type resource struct {
+	// the resource is used by the rendering in progress
+	rendered bool
+	// the resource was used by the previous rendering
+	mounted bool
+	release func()
+}
+
+// Store of the resources, by key.
+var resources = make(map[string]*resource)
+
+// Declares that the rendering in progress uses the resource identified by the key. The release
+// function is called at the end of the first rendering that no longer uses it. Returns true if
+// the resource is mounted: it was not used by the previous rendering.
+func useResource(key string, release func()) bool {
+	utils.MapInit(key, resources, &resource{})
+	r := resources[key]
+	mounting := !r.mounted && !r.rendered
+	r.rendered, r.release = true, release
+	return mounting
+}
+
+// Returns true if the resource identified by the key is used by the application.
+func isMounted(key string) bool {
+	r, isPresent := resources[key]
+	return isPresent && (r.mounted || r.rendered)
+}
+
+// Called at the end of each rendering: the resources that are no longer used are released.
+func releaseResources() {
+	for key, r := range resources {
+		if !r.rendered {
+			delete(resources, key)
+			r.release()
+			continue
+		}
+		r.mounted, r.rendered = true, false
+	}
+}
@@ -0,0 +1,254 @@
+package gooroo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Signals
+
+// The signals are reactive values, an alternative to the rendering of the whole application.
+// The computations (Effect, Computed) reading a signal are run again when it changes, and the
+// DomComponents bound to a signal (SignalText, SignalAttr) patch only their DOM node, without
+// running the functions of the components. The signals are changed on the render loop, so they
+// can be set from any goroutine.
+
+// ReadSignal is a reactive value that can be read, and tracked by the computations.
+type ReadSignal[T any] interface {
+	// Returns the value, and tracks it if a computation is running.
+	Get() T
+	// Returns the value without tracking it.
+	Peek() T
+}
+
+// Signal is a reactive value of type T.
+type Signal[T any] struct {
+	value     T
+	observers map[*computation]bool
+}
+
+// Source is a reactive value observed by computations.
+type source interface {
+	unsubscribe(c *computation)
+}
+
+// Computation is a function run again when one of the signals it has read changes. The
+// computations created while it runs are its children, disposed before it runs again.
+type computation struct {
+	run      func()
+	sources  []source
+	children []*computation
+	disposed bool
+}
+
+// SignalBinding describes an element bound to a signal, patched by an effect after the rendering.
+type signalBinding struct {
+	selector string
+	patch    func(elem js.Value)
+}
+
+var (
+	// Computation being run, which tracks the signals it reads.
+	tracking *computation
+
+	// List of the elements bound to a signal during the rendering.
+	signalBindings = []signalBinding{}
+
+	// Functions disposing the computations of the rendering: the effects patching the elements
+	// bound to a signal, and the computations created by the components.
+	signalEffects = []func(){}
+)
+
+// Returns a signal holding the initial value.
+func NewSignal[T any](initial T) *Signal[T] {
+	return &Signal[T]{initial, make(map[*computation]bool)}
+}
+
+// Returns the value of the signal, and tracks it if a computation is running.
+func (s *Signal[T]) Get() T {
+	if tracking != nil && !tracking.disposed && !s.observers[tracking] {
+		s.observers[tracking] = true
+		tracking.sources = append(tracking.sources, s)
+	}
+	return s.value
+}
+
+// Returns the value of the signal without tracking it.
+func (s *Signal[T]) Peek() T {
+	return s.value
+}
+
+// Sets the value of the signal on the render loop, and runs again the computations reading it if it
+// has changed (compared with reflect.DeepEqual). Can be called from any goroutine.
+func (s *Signal[T]) Set(value T) {
+	enqueue(func() { s.set(value) })
+}
+
+// Same as Set(), but the new value is computed from the current one when the change is applied,
+// so that the changes requested before are not lost.
+func (s *Signal[T]) Update(update func(value T) T) {
+	enqueue(func() { s.set(update(s.value)) })
+}
+
+// Changes the value of the signal and runs again its observers.
+func (s *Signal[T]) set(value T) {
+	if reflect.DeepEqual(s.value, value) {
+		return
+	}
+	s.value = value
+	observers := make([]*computation, 0, len(s.observers))
+	for c := range s.observers {
+		observers = append(observers, c)
+	}
+	for _, c := range observers {
+		if !c.disposed {
+			c.execute()
+		}
+	}
+}
+
+// Stops the observation of the signal by a computation.
+func (s *Signal[T]) unsubscribe(c *computation) {
+	delete(s.observers, c)
+}
+
+// Runs the function of the computation, tracking the signals it reads.
+func (c *computation) execute() {
+	c.disposeChildren()
+	for _, source := range c.sources {
+		source.unsubscribe(c)
+	}
+	c.sources = nil
+	previous := tracking
+	tracking = c
+	defer func() { tracking = previous }()
+	c.run()
+}
+
+// Returns a computation of the function, run immediately. It is a child of the computation
+// running, if any.
+func newComputation(run func()) *computation {
+	c := &computation{run: run}
+	if tracking != nil {
+		tracking.children = append(tracking.children, c)
+	}
+	c.execute()
+	return c
+}
+
+// Stops the computation and its children.
+func (c *computation) dispose() {
+	c.disposed = true
+	c.disposeChildren()
+	for _, source := range c.sources {
+		source.unsubscribe(c)
+	}
+	c.sources = nil
+}
+
+// Stops the computations created by the last run of the computation.
+func (c *computation) disposeChildren() {
+	for _, child := range c.children {
+		child.dispose()
+	}
+	c.children = nil
+}
+
+// Runs the function immediately, then again each time one of the signals it reads changes.
+// Must be called on the render loop: during a rendering, in a DomBinding or in an Update().
+// An effect created by a component is stopped before the next rendering, which creates it again,
+// and an effect created by another computation is stopped before the next run of this one.
+// Returns a function stopping the effect.
+func Effect(effect func()) (dispose func()) {
+	c := newComputation(effect)
+	if atomic.LoadInt32(&rendering) == 1 {
+		signalEffects = append(signalEffects, c.dispose)
+	}
+	return c.dispose
+}
+
+// Returns a signal holding the value of the function, computed again each time one of the signals
+// it reads changes. Must be called on the render loop, and is stopped before the next rendering
+// if created by a component, as Effect().
+func Computed[T any](compute func() T) ReadSignal[T] {
+	s := &Signal[T]{observers: make(map[*computation]bool)}
+	s.value = compute()
+	// the first run of the effect recomputes the same value, only to track its signals
+	Effect(func() { s.set(compute()) })
+	return s
+}
+
+// Registers an element bound to a signal, and returns its marker attribute.
+func bindSignal(patch func(elem js.Value)) string {
+	marker := fmt.Sprintf("%s%s%d", dom.HTML_PARAM_GOOROO, dom.HTML_PARAM_SIGNAL, len(signalBindings))
+	signalBindings = append(signalBindings, signalBinding{fmt.Sprintf("[%s]", marker), patch})
+	return marker
+}
+
+// Declare a <span> displaying the value of a signal, whose text is patched when the signal changes.
+func SignalText[T any](signal ReadSignal[T]) DomComponent {
+	marker := bindSignal(func(elem js.Value) {
+		// the text content is not parsed as html
+		elem.Set(dom.JS_TEXT_CONTENT, fmt.Sprint(signal.Get()))
+	})
+	return Span(fmt.Sprint(signal.Peek()), Attr(marker, ""))
+}
+
+// Declare an attribute of an html element holding the value of a signal, patched when the signal
+// changes. A boolean signal declares a boolean attribute, present only if it is true: its DOM
+// property is patched too, except for the 'aria-*' and 'data-*' attributes, which have none.
+func SignalAttr[T any](name string, signal ReadSignal[T]) DomComponent {
+	marker := bindSignal(func(elem js.Value) {
+		value := any(signal.Get())
+		if boolean, isBool := value.(bool); isBool {
+			if boolean {
+				elem.Call(dom.JS_SET_ATTRIBUTE, name, "")
+			} else {
+				elem.Call(dom.JS_REMOVE_ATTRIBUTE, name)
+			}
+			if lower := strings.ToLower(name); !strings.HasPrefix(lower, dom.HTML_PARAM_ARIA) && !strings.HasPrefix(lower, dom.HTML_PARAM_DATA) {
+				elem.Set(name, boolean)
+			}
+			return
+		}
+		elem.Call(dom.JS_SET_ATTRIBUTE, name, fmt.Sprint(value))
+		if name == dom.JS_VALUE {
+			elem.Set(dom.JS_VALUE, fmt.Sprint(value))
+		}
+	})
+	param := Attr(marker, "")
+	if boolean, isBool := any(signal.Peek()).(bool); isBool {
+		if !boolean {
+			return param
+		}
+		return func() string { return fmt.Sprintf("%s %s", param(), utils.SanitizeName(name)) }
+	}
+	attr := Attr(name, fmt.Sprint(signal.Peek()))
+	return func() string { return fmt.Sprintf("%s %s", param(), strings.TrimPrefix(attr(), dom.ELEMENT_PARAM)) }
+}
+
+// Starts the effects patching the elements bound to a signal after the rendering.
+func setSignals() {
+	for _, binding := range signalBindings {
+		elem := document.Call(dom.JS_QUERY_SELECTOR, binding.selector)
+		if !elem.IsNull() {
+			patch := binding.patch
+			signalEffects = append(signalEffects, newComputation(func() { patch(elem) }).dispose)
+		}
+	}
+}
+
+// Stops the computations of the previous rendering, and deletes the bindings of its elements.
+func unsetSignals() {
+	for _, dispose := range signalEffects {
+		dispose()
+	}
+	signalEffects = []func(){}
+	signalBindings = []signalBinding{}
+}
@@ -0,0 +1,95 @@
+package gooroo
+
+import (
+	"reflect"
+	"runtime"
+
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// Global stores
+
+// A global store holds a state of type S shared by the whole application, changed only by the
+// actions dispatched to its reducer. The components read slices of it through UseSelector, and
+// the application is rendered again only if a selected slice has changed.
+
+const SELECTOR_STORE_PREFIX = "selector:"
+
+// Reducer returns the new state of a store after an action.
+type Reducer[S any] func(state S, action any) S
+
+// Middleware wraps the dispatch of the actions of a store: it can log, transform or delay them,
+// and passes them on by calling next (or not).
+type Middleware[S any] func(store *Store[S], action any, next func(action any))
+
+// Store holds a global state of type S.
+type Store[S any] struct {
+	state         S
+	reducer       Reducer[S]
+	dispatch      func(action any)
+	subscriptions map[string]*subscription[S]
+}
+
+// Subscription describes a slice of a store selected by a component.
+type subscription[S any] struct {
+	selector func(state S) any
+	equal    func(a any, b any) bool
+	value    *any
+}
+
+// Returns a store with its initial state, its reducer and its middlewares. The first middleware
+// is the outermost.
+func NewStore[S any](initial S, reducer Reducer[S], middlewares ...Middleware[S]) *Store[S] {
+	s := &Store[S]{state: initial, reducer: reducer, subscriptions: make(map[string]*subscription[S])}
+	s.dispatch = s.reduce
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		middleware, next := middlewares[i], s.dispatch
+		s.dispatch = func(action any) { middleware(s, action, next) }
+	}
+	return s
+}
+
+// Returns the current state of the store.
+func (s *Store[S]) State() S {
+	return s.state
+}
+
+// Dispatches an action through the middlewares to the reducer, on the render loop. Can be called
+// from any goroutine.
+func (s *Store[S]) Dispatch(action any) {
+	enqueue(func() { s.dispatch(action) })
+}
+
+// Applies an action to the state, and marks as changed the selected slices that differ from
+// their previous value.
+func (s *Store[S]) reduce(action any) {
+	s.state = s.reducer(s.state, action)
+	for _, sub := range s.subscriptions {
+		if selected := sub.selector(s.state); !sub.equal(*sub.value, selected) {
+			setHasChanged(sub.value, selected)
+		}
+	}
+}
+
+// Returns a slice of the state of a store. After each action, the application is rendered again
+// only if the slice has changed, compared with reflect.DeepEqual or with the equality function
+// passed in parameter.
+func UseSelector[S any, T any](s *Store[S], selector func(state S) T, equal ...func(a T, b T) bool) T {
+	_, file, no, _ := runtime.Caller(1)
+	key := SELECTOR_STORE_PREFIX + utils.CallerToKey(file, no)
+	selected := selector(s.state)
+	value, _ := UseStateWithKey(key, any(selected))
+	// the selector may depend on the variables of the rendering
+	*value = selected
+	isEqual := func(a any, b any) bool { return reflect.DeepEqual(a, b) }
+	if len(equal) > 0 {
+		isEqual = func(a any, b any) bool {
+			typedA, _ := a.(T)
+			typedB, _ := b.(T)
+			return equal[0](typedA, typedB)
+		}
+	}
+	s.subscriptions[key] = &subscription[S]{func(state S) any { return selector(state) }, isEqual, value}
+	useResource(key, func() { delete(s.subscriptions, key) })
+	return selected
+}
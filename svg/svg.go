@@ -0,0 +1,270 @@
+// The svg package gathers the DomComponents of the SVG elements and attributes.
+// They are rendered as any other DomComponent and can be nested in the html elements of the
+// gooroo package: the content of an <svg> is parsed in the SVG namespace.
+package svg
+
+import (
+	"fmt"
+
+	"github.com/Matbabs/Gooroo"
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// SvgElements
+
+// Declare an svg element with the <svg> tag.
+func Svg(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_SVG, insiders...)
+}
+
+// Declare an svg element with the <g> tag.
+func G(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_G, insiders...)
+}
+
+// Declare an svg element with the <defs> tag.
+func Defs(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_DEFS, insiders...)
+}
+
+// Declare an svg element with the <symbol> tag.
+func Symbol(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_SYMBOL, insiders...)
+}
+
+// Declare an svg element with the <use> tag.
+func Use(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_USE, insiders...)
+}
+
+// Declare an svg element with the <title> tag.
+func Title[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_TITLE, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare an svg element with the <desc> tag.
+func Desc[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_DESC, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare an svg element with the <path> tag.
+func Path(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_PATH, insiders...)
+}
+
+// Declare an svg element with the <circle> tag.
+func Circle(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_CIRCLE, insiders...)
+}
+
+// Declare an svg element with the <ellipse> tag.
+func Ellipse(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_ELLIPSE, insiders...)
+}
+
+// Declare an svg element with the <rect> tag.
+func Rect(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_RECT, insiders...)
+}
+
+// Declare an svg element with the <line> tag.
+func Line(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_LINE, insiders...)
+}
+
+// Declare an svg element with the <polyline> tag.
+func Polyline(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_POLYLINE, insiders...)
+}
+
+// Declare an svg element with the <polygon> tag.
+func Polygon(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_POLYGON, insiders...)
+}
+
+// Declare an svg element with the <text> tag.
+func Text[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_TEXT, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare an svg element with the <tspan> tag.
+func TSpan[T string | int | int32 | int64 | float32 | float64 | bool](text T, insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_TSPAN, append([]gooroo.DomComponent{gooroo.Text(text)}, insiders...)...)
+}
+
+// Declare an svg element with the <image> tag.
+func Image(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_IMAGE, insiders...)
+}
+
+// Declare an svg element with the <foreignObject> tag, to embed html elements in an svg.
+func ForeignObject(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_FOREIGN_OBJECT, insiders...)
+}
+
+// Declare an svg element with the <linearGradient> tag.
+func LinearGradient(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_LINEAR_GRADIENT, insiders...)
+}
+
+// Declare an svg element with the <radialGradient> tag.
+func RadialGradient(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_RADIAL_GRADIENT, insiders...)
+}
+
+// Declare an svg element with the <stop> tag.
+func Stop(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_STOP, insiders...)
+}
+
+// Declare an svg element with the <clipPath> tag.
+func ClipPath(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_CLIP_PATH, insiders...)
+}
+
+// Declare an svg element with the <mask> tag.
+func Mask(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_MASK, insiders...)
+}
+
+// Declare an svg element with the <pattern> tag.
+func Pattern(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_PATTERN, insiders...)
+}
+
+// Declare an svg element with the <marker> tag.
+func Marker(insiders ...gooroo.DomComponent) gooroo.DomComponent {
+	return gooroo.El(dom.SVG_MARKER, insiders...)
+}
+
+// SvgParams
+
+// Declare the attribute 'viewBox=' of an svg element.
+func ViewBox[T int | int32 | int64 | float32 | float64](minX T, minY T, width T, height T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_VIEWBOX, fmt.Sprintf("%v %v %v %v", minX, minY, width, height))
+}
+
+// Declare the attribute 'width=' of an svg element.
+func Width[T string | int | int32 | int64 | float32 | float64](width T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_WIDTH, width)
+}
+
+// Declare the attribute 'height=' of an svg element.
+func Height[T string | int | int32 | int64 | float32 | float64](height T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_HEIGHT, height)
+}
+
+// Declare the attribute 'x=' of an svg element.
+func X[T string | int | int32 | int64 | float32 | float64](x T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_X, x)
+}
+
+// Declare the attribute 'y=' of an svg element.
+func Y[T string | int | int32 | int64 | float32 | float64](y T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_Y, y)
+}
+
+// Declare the attribute 'cx=' of an svg element.
+func Cx[T string | int | int32 | int64 | float32 | float64](cx T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_CX, cx)
+}
+
+// Declare the attribute 'cy=' of an svg element.
+func Cy[T string | int | int32 | int64 | float32 | float64](cy T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_CY, cy)
+}
+
+// Declare the attribute 'r=' of an svg element.
+func R[T string | int | int32 | int64 | float32 | float64](r T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_R, r)
+}
+
+// Declare the attribute 'rx=' of an svg element.
+func Rx[T string | int | int32 | int64 | float32 | float64](rx T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_RX, rx)
+}
+
+// Declare the attribute 'ry=' of an svg element.
+func Ry[T string | int | int32 | int64 | float32 | float64](ry T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_RY, ry)
+}
+
+// Declare the attribute 'x1=' of an svg element.
+func X1[T string | int | int32 | int64 | float32 | float64](x1 T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_X1, x1)
+}
+
+// Declare the attribute 'y1=' of an svg element.
+func Y1[T string | int | int32 | int64 | float32 | float64](y1 T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_Y1, y1)
+}
+
+// Declare the attribute 'x2=' of an svg element.
+func X2[T string | int | int32 | int64 | float32 | float64](x2 T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_X2, x2)
+}
+
+// Declare the attribute 'y2=' of an svg element.
+func Y2[T string | int | int32 | int64 | float32 | float64](y2 T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_Y2, y2)
+}
+
+// Declare the attribute 'd=' of a <path>, which describes its drawing commands.
+func D(d string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_D, d)
+}
+
+// Declare the attribute 'points=' of a <polyline> or a <polygon>.
+func Points(points string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_POINTS, points)
+}
+
+// Declare the attribute 'fill=' of an svg element.
+func Fill(fill string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_FILL, fill)
+}
+
+// Declare the attribute 'stroke=' of an svg element.
+func Stroke(stroke string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_STROKE, stroke)
+}
+
+// Declare the attribute 'stroke-width=' of an svg element.
+func StrokeWidth[T string | int | int32 | int64 | float32 | float64](strokeWidth T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_STROKE_WIDTH, strokeWidth)
+}
+
+// Declare the attribute 'opacity=' of an svg element.
+func Opacity[T string | int | int32 | int64 | float32 | float64](opacity T) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_OPACITY, opacity)
+}
+
+// Declare the attribute 'transform=' of an svg element.
+func Transform(transform string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_TRANSFORM, transform)
+}
+
+// Declare the attribute 'href=' of a <use> or an <image>.
+func Href(href string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_HREF, href)
+}
+
+// Declare the attribute 'offset=' of a gradient <stop>.
+func Offset(offset string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_OFFSET, offset)
+}
+
+// Declare the attribute 'stop-color=' of a gradient <stop>.
+func StopColor(stopColor string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_STOP_COLOR, stopColor)
+}
+
+// Declare the attribute 'text-anchor=' of a <text>.
+func TextAnchor(textAnchor string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_TEXT_ANCHOR, textAnchor)
+}
+
+// Declare the attribute 'preserveAspectRatio=' of an <svg>.
+func PreserveAspectRatio(preserveAspectRatio string) gooroo.DomComponent {
+	return gooroo.Attr(dom.SVG_PARAM_PRESERVE_ASPECT_RATIO, preserveAspectRatio)
+}
@@ -0,0 +1,36 @@
+package utils
+
+import "sync"
+
+// Queue collects functions pushed from any goroutine, to run them later on a single goroutine,
+// in the order in which they were pushed.
+type Queue struct {
+	mutex sync.Mutex
+	funcs []func()
+}
+
+// Adds a function to the queue. Safe to call from any goroutine.
+func (q *Queue) Push(f func()) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.funcs = append(q.funcs, f)
+}
+
+// Runs and removes all the functions of the queue, including the ones pushed while draining.
+// Returns the number of functions run.
+func (q *Queue) Drain() int {
+	count := 0
+	for {
+		q.mutex.Lock()
+		funcs := q.funcs
+		q.funcs = nil
+		q.mutex.Unlock()
+		if len(funcs) == 0 {
+			return count
+		}
+		for _, f := range funcs {
+			f()
+		}
+		count += len(funcs)
+	}
+}
@@ -0,0 +1,53 @@
+// go test -race ./utils
+
+package utils
+
+import (
+	"sync"
+	"testing"
+)
+
+func Test_QueueConcurrentPush(t *testing.T) {
+	queue := Queue{}
+	total := 0
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				queue.Push(func() { total++ })
+			}
+		}()
+	}
+	done := make(chan bool)
+	go func() {
+		// drain concurrently with the pushes, as the render loop does
+		for i := 0; i < 50; i++ {
+			queue.Drain()
+		}
+		done <- true
+	}()
+	wg.Wait()
+	<-done
+	queue.Drain()
+	if total != 1000 {
+		t.Errorf("Expected 1000 functions run, got %d", total)
+	}
+}
+
+func Test_QueueOrderAndReentrance(t *testing.T) {
+	queue := Queue{}
+	order := []int{}
+	queue.Push(func() {
+		order = append(order, 1)
+		queue.Push(func() { order = append(order, 3) })
+	})
+	queue.Push(func() { order = append(order, 2) })
+	if count := queue.Drain(); count != 3 || len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Errorf("Unexpected order %v (%d functions run)", order, count)
+	}
+	if queue.Drain() != 0 {
+		t.Error("Queue not empty after draining")
+	}
+}
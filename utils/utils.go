@@ -3,7 +3,10 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // Check for the presence of a 'string' in a '[]string'.
@@ -42,3 +45,109 @@ func CallerToKey(file string, no int) string {
 func AnyStr(v any) string {
 	return fmt.Sprintf("%v", v)
 }
+
+// Removes the characters that are not allowed in a tag or an attribute name.
+func SanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == ':' || r == '.' {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// Returns the longest of two durations.
+func MaxDuration(a time.Duration, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Identifies a pointer, a map or a slice already copied, by its address and its type (a struct
+// and its first field have the same address) and by its length for a slice.
+type reference struct {
+	address uintptr
+	typ     reflect.Type
+	length  int
+}
+
+// Returns a copy of a value sharing no memory with it: the slices, maps, pointers and interfaces
+// it holds are copied recursively, and the cycles (parent links, circular lists ...) are copied as
+// cycles. The unexported fields of the structs are copied as is: the slices, maps and pointers
+// they hold are shared with the original.
+func DeepCopy[T any](value T) T {
+	copied, _ := deepCopy(reflect.ValueOf(&value).Elem(), make(map[reference]reflect.Value)).Interface().(T)
+	return copied
+}
+
+// Copies a reflected value recursively. The copies of the pointers, maps and slices are retained,
+// to be reused when they are found again.
+func deepCopy(value reflect.Value, copies map[reference]reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		ref := reference{value.Pointer(), value.Type(), 0}
+		if copied, isCopied := copies[ref]; isCopied {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		copies[ref] = copied
+		copied.Elem().Set(deepCopy(value.Elem(), copies))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(deepCopy(value.Elem(), copies))
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		ref := reference{value.Pointer(), value.Type(), value.Len()}
+		if copied, isCopied := copies[ref]; isCopied {
+			return copied
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		copies[ref] = copied
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i), copies))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i), copies))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		ref := reference{value.Pointer(), value.Type(), 0}
+		if copied, isCopied := copies[ref]; isCopied {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		copies[ref] = copied
+		iter := value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copies))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(value.Field(i), copies))
+			}
+		}
+		return copied
+	}
+	return value
+}
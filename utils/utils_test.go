@@ -0,0 +1,57 @@
+// go test ./utils
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_DeepCopy(t *testing.T) {
+	type shape struct {
+		Points [][2]int
+		Tags   map[string][]string
+		Parent *shape
+		Extra  any
+		At     time.Time
+		hidden []int
+	}
+	original := shape{
+		Points: [][2]int{{1, 2}},
+		Tags:   map[string][]string{"color": {"red"}},
+		Parent: &shape{Points: [][2]int{{0, 0}}},
+		Extra:  []string{"a"},
+		At:     time.UnixMilli(1000),
+		hidden: []int{1},
+	}
+	copied := DeepCopy(original)
+	copied.Points[0][0] = 9
+	copied.Tags["color"][0] = "blue"
+	copied.Parent.Points = append(copied.Parent.Points, [2]int{1, 1})
+	copied.Extra.([]string)[0] = "b"
+	if original.Points[0][0] != 1 || original.Tags["color"][0] != "red" || len(original.Parent.Points) != 1 || original.Extra.([]string)[0] != "a" {
+		t.Errorf("Copy shares memory with the original %+v", original)
+	}
+	if !copied.At.Equal(original.At) || len(copied.hidden) != 1 {
+		t.Error("Unexported fields not copied")
+	}
+	if DeepCopy[any](nil) != nil || DeepCopy([]int(nil)) != nil {
+		t.Error("Nil values not kept")
+	}
+	type node struct {
+		Value int
+		Next  *node
+		Links map[string]any
+	}
+	list := &node{Value: 1, Links: map[string]any{}}
+	list.Next = &node{Value: 2, Next: list}
+	list.Links["self"] = list.Links
+	cycle := DeepCopy(list)
+	if cycle == list || cycle.Next.Next != cycle || cycle.Next.Value != 2 {
+		t.Error("Cycle not copied as a cycle")
+	}
+	cycle.Links["self"].(map[string]any)["added"] = true
+	if len(list.Links) != 1 || len(cycle.Links) != 2 {
+		t.Error("Cyclic map not copied as a cycle")
+	}
+}
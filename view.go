@@ -0,0 +1,162 @@
+package gooroo
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+
+	"github.com/Matbabs/Gooroo/dom"
+)
+
+// ElementLocator retains how to find an element once the DOM has been rendered again: by the
+// selector of its id, its name or its binding attribute, and otherwise by its position from the
+// root of the application, which changes when the elements before it are inserted or removed.
+type elementLocator struct {
+	selector string
+	path     []int
+	tagName  string
+}
+
+// ScrollState retains the scroll offsets of a scrolled element.
+type scrollState struct {
+	locator elementLocator
+	top     float64
+	left    float64
+}
+
+// ViewState retains the state of the view that a rendering would lose: the focused element and
+// its selection, and the scroll offsets of the window and of the scrolled elements.
+type viewState struct {
+	focused            *elementLocator
+	selectionStart     js.Value
+	selectionEnd       js.Value
+	selectionDirection js.Value
+	scrollX            float64
+	scrollY            float64
+	scrolls            []scrollState
+}
+
+// Listens to the scroll of the elements of the application, to restore their scroll offsets
+// after the renderings.
+func trackScrolls() {
+	scrolledElements = js.Global().Get(dom.JS_SET).New()
+	document.Get(dom.HTML_BODY).Call(dom.JS_ADD_EVENT_LISTENER, dom.JS_EVENT_SCROLL, js.FuncOf(func(_ js.Value, args []js.Value) any {
+		scrolledElements.Call(dom.JS_ADD, args[0].Get(dom.JS_TARGET))
+		return nil
+	}), map[string]any{dom.JS_OPTION_CAPTURE: true, dom.JS_OPTION_PASSIVE: true})
+}
+
+// Returns the locator of an element, or nil if it is not in the application.
+func locateElement(target js.Value) *elementLocator {
+	root := document.Get(dom.HTML_BODY)
+	indexOf := js.Global().Get(dom.JS_ARRAY).Get(dom.JS_PROTOTYPE).Get(dom.JS_INDEX_OF)
+	path := []int{}
+	elem := target
+	for ; elem.Truthy() && !elem.Equal(root); elem = elem.Get(dom.JS_PARENT_ELEMENT) {
+		parent := elem.Get(dom.JS_PARENT_ELEMENT)
+		if !parent.Truthy() {
+			return nil
+		}
+		path = append([]int{indexOf.Call(dom.JS_CALL, parent.Get(dom.JS_CHILDREN), elem).Int()}, path...)
+	}
+	if !elem.Truthy() || len(path) == 0 {
+		return nil
+	}
+	return &elementLocator{elementSelector(target), path, target.Get(dom.JS_TAG_NAME).String()}
+}
+
+// Returns the selector of an element from its id, its name or its first binding attribute, or ""
+// if it has none of them.
+func elementSelector(elem js.Value) string {
+	attributes := []string{dom.JS_ID, dom.HTML_PARAM_NAME}
+	elemAttributes := elem.Get(dom.JS_ATTRIBUTES)
+	for a := 0; a < elemAttributes.Length(); a++ {
+		if name := elemAttributes.Index(a).Get(dom.JS_NAME).String(); strings.HasPrefix(name, dom.HTML_PARAM_GOOROO+dom.JS_EVENT_PREFIX) {
+			attributes = append(attributes, name)
+		}
+	}
+	for _, attribute := range attributes {
+		value := elem.Call(dom.JS_GET_ATTRIBUTE, attribute)
+		if value.Type() != js.TypeString || value.String() == "" || strings.ContainsAny(value.String(), `'\`) {
+			continue
+		}
+		if strings.HasPrefix(attribute, dom.HTML_PARAM_GOOROO) {
+			return bindingSelector(attribute, strings.Fields(value.String())[0])
+		}
+		return fmt.Sprintf("[%s='%s']", attribute, value.String())
+	}
+	return ""
+}
+
+// Returns the only element matching the selector of the locator, or else the element found at
+// its position, or undefined if there is no element with the same tag at this position.
+func (l elementLocator) find() js.Value {
+	if l.selector != "" {
+		elems := document.Get(dom.HTML_BODY).Call(dom.JS_QUERY_SELECTOR_ALL, l.selector)
+		if elems.Length() == 1 && elems.Index(0).Get(dom.JS_TAG_NAME).String() == l.tagName {
+			return elems.Index(0)
+		}
+	}
+	elem := document.Get(dom.HTML_BODY)
+	for _, index := range l.path {
+		elem = elem.Get(dom.JS_CHILDREN).Index(index)
+		if !elem.Truthy() {
+			return js.Undefined()
+		}
+	}
+	if elem.Get(dom.JS_TAG_NAME).String() != l.tagName {
+		return js.Undefined()
+	}
+	return elem
+}
+
+// Saves the state of the view before a rendering.
+func saveView() viewState {
+	window := js.Global()
+	view := viewState{
+		scrollX: window.Get(dom.JS_SCROLL_X).Float(),
+		scrollY: window.Get(dom.JS_SCROLL_Y).Float(),
+	}
+	active := document.Get(dom.JS_ACTIVE_ELEMENT)
+	if view.focused = locateElement(active); view.focused != nil {
+		view.selectionStart = active.Get(dom.JS_SELECTION_START)
+		view.selectionEnd = active.Get(dom.JS_SELECTION_END)
+		view.selectionDirection = active.Get(dom.JS_SELECTION_DIRECTION)
+	}
+	if scrolledElements.Truthy() {
+		elems := js.Global().Get(dom.JS_ARRAY).Call(dom.JS_FROM, scrolledElements)
+		for i := 0; i < elems.Length(); i++ {
+			elem := elems.Index(i)
+			if !elem.Get(dom.JS_IS_CONNECTED).Bool() {
+				continue
+			}
+			if locator := locateElement(elem); locator != nil {
+				view.scrolls = append(view.scrolls, scrollState{*locator, elem.Get(dom.JS_SCROLL_TOP).Float(), elem.Get(dom.JS_SCROLL_LEFT).Float()})
+			}
+		}
+		scrolledElements.Call(dom.JS_CLEAR)
+	}
+	return view
+}
+
+// Restores the state of the view on the elements rendered again.
+func restoreView(view viewState) {
+	for _, scroll := range view.scrolls {
+		if elem := scroll.locator.find(); elem.Truthy() {
+			elem.Set(dom.JS_SCROLL_TOP, scroll.top)
+			elem.Set(dom.JS_SCROLL_LEFT, scroll.left)
+			scrolledElements.Call(dom.JS_ADD, elem)
+		}
+	}
+	js.Global().Call(dom.JS_SCROLL_TO, view.scrollX, view.scrollY)
+	if view.focused == nil {
+		return
+	}
+	if elem := view.focused.find(); elem.Truthy() {
+		elem.Call(dom.JS_EVENT_FOCUS, map[string]any{dom.JS_PREVENT_SCROLL: true})
+		// selectionStart is null for the elements without text selection (checkbox ...)
+		if view.selectionStart.Type() == js.TypeNumber && elem.Get(dom.JS_SELECTION_START).Type() == js.TypeNumber {
+			elem.Call(dom.JS_SET_SELECTION_RANGE, view.selectionStart, view.selectionEnd, view.selectionDirection)
+		}
+	}
+}
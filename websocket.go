@@ -0,0 +1,212 @@
+package gooroo
+
+import (
+	"encoding/json"
+	"errors"
+	"runtime"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/Matbabs/Gooroo/dom"
+	"github.com/Matbabs/Gooroo/utils"
+)
+
+// WebSockets
+
+const WEBSOCKET_RESOURCE_PREFIX = "websocket:"
+
+// WebSocket describes the state of the socket opened by UseWebSocket.
+type WebSocket[T any] struct {
+	// State of the connection.
+	Status SocketStatus
+	// Last message received, decoded from JSON (or the raw text if T is a string, and the raw bytes
+	// if T is a []byte). The binary frames are received as bytes.
+	Message T
+	// Error of the connection or of the decoding of the last message, or nil.
+	Error error
+	// Sends a message: a string as is, a []byte in a binary frame, any other value encoded in JSON.
+	// Fails if the socket is not open. Can be called from any goroutine.
+	Send func(message any) error
+}
+
+// SocketEntry retains a socket and its state across the renderings.
+type socketEntry struct {
+	url       string
+	options   connectionOptions
+	socket    js.Value
+	listeners map[string]js.Func
+	status    SocketStatus
+	message   string
+	received  bool
+	err       error
+	attempts  int
+	reconnect *time.Timer
+	closed    bool
+	// Guards the socket and its status, set on the render loop and read by Send from any goroutine.
+	mu sync.Mutex
+}
+
+// Store of the sockets, by position of their hook.
+var sockets = make(map[string]*socketEntry)
+
+// Returns the state of a socket connected to the URL, with its last message decoded in a value
+// of type T. The socket is reconnected with an exponential backoff when the connection is lost,
+// and closed when the component using it is unmounted. Each event of the socket renders the
+// application again.
+func UseWebSocket[T any](url string, options ...WebSocketOption) WebSocket[T] {
+	_, file, no, _ := runtime.Caller(1)
+	key := utils.CallerToKey(file, no)
+	utils.MapInit(key, sockets, &socketEntry{})
+	entry := sockets[key]
+	entry.options = newConnectionOptions()
+	for _, option := range options {
+		option.applyWebSocket(&entry.options)
+	}
+	useResource(WEBSOCKET_RESOURCE_PREFIX+key, func() {
+		entry.close()
+		delete(sockets, key)
+	})
+	if entry.url != url {
+		entry.close()
+		entry.url, entry.closed, entry.attempts = url, false, 0
+		entry.received, entry.message = false, ""
+		entry.connect()
+	}
+	ws := WebSocket[T]{Status: entry.status, Error: entry.err, Send: entry.send}
+	if entry.received {
+		message, err := decodeMessage[T](entry.message)
+		ws.Message = message
+		if err != nil && ws.Error == nil {
+			ws.Error = err
+		}
+	}
+	return ws
+}
+
+// Opens the socket, and listens to its events on the render loop.
+func (entry *socketEntry) connect() {
+	protocols := make([]any, len(entry.options.protocols))
+	for i, protocol := range entry.options.protocols {
+		protocols[i] = protocol
+	}
+	socket := js.Global().Get(dom.JS_WEB_SOCKET).New(entry.url, protocols)
+	socket.Set(dom.JS_BINARY_TYPE, dom.JS_BINARY_TYPE_ARRAY_BUFFER)
+	entry.setState(socket, SOCKET_CONNECTING)
+	entry.listeners = map[string]js.Func{
+		dom.JS_EVENT_OPEN: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			Update(func() {
+				entry.setState(entry.socket, SOCKET_OPEN)
+				entry.err, entry.attempts = nil, 0
+			})
+			return nil
+		}),
+		dom.JS_EVENT_MESSAGE: js.FuncOf(func(_ js.Value, args []js.Value) any {
+			data := args[0].Get(dom.JS_DATA)
+			message := data.String()
+			if data.Type() != js.TypeString {
+				// the binary frames are received as an ArrayBuffer
+				array := js.Global().Get(dom.JS_UINT8_ARRAY).New(data)
+				bytes := make([]byte, array.Length())
+				js.CopyBytesToGo(bytes, array)
+				message = string(bytes)
+			}
+			Update(func() {
+				entry.message, entry.received = message, true
+			})
+			return nil
+		}),
+		dom.JS_EVENT_ERROR: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			Update(func() {
+				entry.err = errors.New("websocket: connection error")
+			})
+			return nil
+		}),
+		dom.JS_EVENT_CLOSE: js.FuncOf(func(_ js.Value, _ []js.Value) any {
+			Update(func() {
+				if entry.socket.Equal(socket) {
+					entry.setState(socket, SOCKET_CLOSED)
+					entry.scheduleReconnect()
+				}
+			})
+			return nil
+		}),
+	}
+	for event, listener := range entry.listeners {
+		socket.Call(dom.JS_ADD_EVENT_LISTENER, event, listener)
+	}
+}
+
+// Reconnects the socket after a delay doubled at each failed attempt, unless it has been closed or
+// replaced meanwhile (by a change of URL after the delay, whose reconnection is already queued).
+func (entry *socketEntry) scheduleReconnect() {
+	delay, canReconnect := entry.options.backoff(entry.attempts)
+	if entry.closed || !canReconnect {
+		return
+	}
+	entry.attempts++
+	socket := entry.socket
+	entry.reconnect = time.AfterFunc(delay, func() {
+		Update(func() {
+			if !entry.closed && entry.socket.Equal(socket) {
+				entry.releaseListeners()
+				entry.connect()
+			}
+		})
+	})
+}
+
+// Closes the socket and stops its reconnections.
+func (entry *socketEntry) close() {
+	entry.closed = true
+	if entry.reconnect != nil {
+		entry.reconnect.Stop()
+	}
+	if entry.socket.Truthy() {
+		entry.socket.Call(dom.JS_CLOSE, dom.JS_CLOSE_NORMAL)
+		entry.releaseListeners()
+	}
+	entry.setState(js.Undefined(), SOCKET_CLOSED)
+}
+
+// Sets the socket and its status.
+func (entry *socketEntry) setState(socket js.Value, status SocketStatus) {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.socket, entry.status = socket, status
+}
+
+// Removes the listeners of the socket and releases their functions.
+func (entry *socketEntry) releaseListeners() {
+	for event, listener := range entry.listeners {
+		entry.socket.Call(dom.JS_REMOVE_EVENT_LISTENER, event, listener)
+		listener.Release()
+	}
+	entry.listeners = nil
+}
+
+// Sends a message through the socket: a string as is, a []byte in a binary frame, any other value
+// encoded in JSON. Can be called from any goroutine.
+func (entry *socketEntry) send(message any) error {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.status != SOCKET_OPEN || !entry.socket.Truthy() {
+		return errors.New("websocket: socket not open")
+	}
+	if bytes, isBytes := message.([]byte); isBytes {
+		array := js.Global().Get(dom.JS_UINT8_ARRAY).New(len(bytes))
+		js.CopyBytesToJS(array, bytes)
+		entry.socket.Call(dom.JS_SEND, array)
+		return nil
+	}
+	text, isText := message.(string)
+	if !isText {
+		encoded, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+		text = string(encoded)
+	}
+	entry.socket.Call(dom.JS_SEND, text)
+	return nil
+}